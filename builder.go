@@ -0,0 +1,174 @@
+package specgen
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// SpecBuilder collects routes incrementally, e.g. from several packages, and
+// reflects them into a single OpenAPI spec on Build.
+type SpecBuilder struct {
+	config SpecConfig
+	routes []Route
+}
+
+func NewSpecBuilder(config SpecConfig) *SpecBuilder {
+	return &SpecBuilder{config: config}
+}
+
+func (b *SpecBuilder) AddRoute(route Route) *SpecBuilder {
+	b.routes = append(b.routes, route)
+	return b
+}
+
+func (b *SpecBuilder) AddRoutes(routes ...Route) *SpecBuilder {
+	b.routes = append(b.routes, routes...)
+	return b
+}
+
+func (b *SpecBuilder) Routes() []Route {
+	return slices.Clone(b.routes)
+}
+
+func (b *SpecBuilder) Group(config RouteGroupConfig) *RouteGroup {
+	return &RouteGroup{builder: b, config: config}
+}
+
+func (b *SpecBuilder) Reflector() (*openapi3.Reflector, error) {
+	return buildReflector(b.config, b.routes)
+}
+
+func (b *SpecBuilder) Build() (*openapi3.Spec, error) {
+	reflector, err := b.Reflector()
+	if err != nil {
+		return nil, err
+	}
+	return reflector.Spec, nil
+}
+
+func (b *SpecBuilder) Bytes(format OutputFormat) ([]byte, error) {
+	spec, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return marshalSpec(spec, format)
+}
+
+func (b *SpecBuilder) Write(w io.Writer, format OutputFormat) error {
+	spec, err := b.Bytes(format)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(spec); err != nil {
+		return fmt.Errorf("failed to write spec: %w", err)
+	}
+
+	return nil
+}
+
+func (b *SpecBuilder) WriteFile(outputFile string) error {
+	spec, err := b.Bytes(FormatFromPath(outputFile))
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputFile, spec, 0644); err != nil {
+		return fmt.Errorf("failed to write spec file: %w", err)
+	}
+
+	return nil
+}
+
+type RouteGroupConfig struct {
+	PathPrefix string
+	Tags       []string
+	// Security is used by routes that do not declare their own.
+	Security []SecurityRequirement
+	// Responses are added to every route unless the route declares the same status code.
+	Responses []RouteResponse
+}
+
+type RouteGroup struct {
+	builder *SpecBuilder
+	config  RouteGroupConfig
+}
+
+func (g *RouteGroup) AddRoute(route Route) *RouteGroup {
+	g.builder.AddRoute(g.apply(route))
+	return g
+}
+
+func (g *RouteGroup) AddRoutes(routes ...Route) *RouteGroup {
+	for _, route := range routes {
+		g.AddRoute(route)
+	}
+	return g
+}
+
+// Group creates a nested group inheriting this group's prefix, tags, security and responses.
+func (g *RouteGroup) Group(config RouteGroupConfig) *RouteGroup {
+	security := config.Security
+	if security == nil {
+		security = g.config.Security
+	}
+
+	return &RouteGroup{
+		builder: g.builder,
+		config: RouteGroupConfig{
+			PathPrefix: joinPath(g.config.PathPrefix, config.PathPrefix),
+			Tags:       mergeTags(g.config.Tags, config.Tags),
+			Security:   security,
+			Responses:  mergeResponses(g.config.Responses, config.Responses),
+		},
+	}
+}
+
+func (g *RouteGroup) apply(route Route) Route {
+	route.Path = joinPath(g.config.PathPrefix, route.Path)
+	route.Tags = mergeTags(g.config.Tags, route.Tags)
+	route.Responses = mergeResponses(g.config.Responses, route.Responses)
+	if route.Security == nil {
+		route.Security = g.config.Security
+	}
+	return route
+}
+
+func joinPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" || path == "/" {
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+func mergeTags(base, tags []string) []string {
+	merged := slices.Clone(base)
+	for _, tag := range tags {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+// mergeResponses returns the responses followed by base responses whose status code is not overridden.
+func mergeResponses(base, responses []RouteResponse) []RouteResponse {
+	merged := slices.Clone(responses)
+	for _, response := range base {
+		overridden := slices.ContainsFunc(responses, func(r RouteResponse) bool {
+			return r.StatusCode == response.StatusCode
+		})
+		if !overridden {
+			merged = append(merged, response)
+		}
+	}
+	return merged
+}
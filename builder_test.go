@@ -0,0 +1,150 @@
+package specgen_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type GroupedSpec struct {
+	Paths map[string]map[string]struct {
+		Tags      []string              `yaml:"tags"`
+		Security  []map[string][]string `yaml:"security"`
+		Responses map[string]yaml.Node  `yaml:"responses"`
+	} `yaml:"paths"`
+}
+
+func TestSpecBuilder_Groups(t *testing.T) {
+	title := "Builder API"
+	builder := specgen.NewSpecBuilder(specgen.SpecConfig{Title: &title, WithBearerTokenSecurity: true})
+
+	api := builder.Group(specgen.RouteGroupConfig{
+		PathPrefix: "/api/v1",
+		Security:   []specgen.SecurityRequirement{{"Bearer Auth": {}}},
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 401, Response: ErrorResponse{}},
+			{StatusCode: 500, Response: ErrorResponse{}},
+		},
+	})
+
+	users := api.Group(specgen.RouteGroupConfig{
+		PathPrefix: "/users",
+		Tags:       []string{"users"},
+	})
+	users.AddRoutes(
+		specgen.Route{
+			Path:    "/",
+			Method:  "GET",
+			Request: struct{}{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 200, Response: []UserResponse{}},
+			},
+		},
+		specgen.Route{
+			Path:   "/{id}",
+			Method: "GET",
+			Request: struct {
+				ID int `path:"id"`
+			}{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 200, Response: UserResponse{}},
+				{StatusCode: 500, Response: struct {
+					Reason string `json:"reason"`
+				}{}},
+			},
+		},
+	)
+
+	builder.AddRoute(specgen.Route{
+		Path:     "/health",
+		Method:   "GET",
+		Request:  struct{}{},
+		Security: []specgen.SecurityRequirement{},
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 200, Response: struct{}{}},
+		},
+	})
+
+	if got := len(builder.Routes()); got != 3 {
+		t.Fatalf("Expected 3 routes, got %d", got)
+	}
+
+	var buf bytes.Buffer
+	if err := builder.Write(&buf, specgen.FormatYAML); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	var spec GroupedSpec
+	if err := yaml.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	list, ok := spec.Paths["/api/v1/users"]["get"]
+	if !ok {
+		t.Fatalf("Expected prefixed path /api/v1/users, got paths: %v", spec.Paths)
+	}
+	if len(list.Tags) != 1 || list.Tags[0] != "users" {
+		t.Errorf("Expected group tags [users], got: %v", list.Tags)
+	}
+	if len(list.Security) != 1 {
+		t.Errorf("Expected group security requirement, got: %v", list.Security)
+	} else if _, ok := list.Security[0]["Bearer Auth"]; !ok {
+		t.Errorf("Expected Bearer Auth requirement, got: %v", list.Security)
+	}
+	for _, code := range []string{"200", "401", "500"} {
+		if _, ok := list.Responses[code]; !ok {
+			t.Errorf("Expected response %s on list route", code)
+		}
+	}
+
+	get, ok := spec.Paths["/api/v1/users/{id}"]["get"]
+	if !ok {
+		t.Fatal("Expected prefixed path /api/v1/users/{id}")
+	}
+	if len(get.Responses) != 3 {
+		t.Errorf("Expected route 500 response to override the group one, got %d responses", len(get.Responses))
+	}
+
+	health := spec.Paths["/health"]["get"]
+	if len(health.Security) != 0 {
+		t.Errorf("Expected no security on ungrouped route, got: %v", health.Security)
+	}
+}
+
+func TestSpecBuilder_WriteFile(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "spec.json")
+
+	builder := specgen.NewSpecBuilder(specgen.SpecConfig{})
+	builder.AddRoute(specgen.Route{
+		Path:    "/health",
+		Method:  "GET",
+		Request: struct{}{},
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 200, Response: struct{}{}},
+		},
+	})
+
+	if err := builder.WriteFile(outputFile); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if len(content) == 0 || content[0] != '{' {
+		t.Error("Expected JSON output for .json file")
+	}
+
+	spec, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if _, ok := spec.Paths.MapOfPathItemValues["/health"]; !ok {
+		t.Error("Expected built spec to contain /health")
+	}
+}
@@ -55,6 +55,9 @@ func main() {
 		Description:             &description,
 		Version:                 &version,
 		WithBearerTokenSecurity: true,
+		Tags: []specgen.SpecTag{
+			{Name: "users", Description: "Operations about users"},
+		},
 	}
 
 	// Define routes
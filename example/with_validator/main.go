@@ -46,6 +46,12 @@ func main() {
 			Path:        "/users",
 			Method:      "POST",
 			Request:     CreateUserRequest{},
+			Responses: []specgen.RouteResponse{
+				{
+					StatusCode: 201,
+					Response:   nil,
+				},
+			},
 		},
 	}
 
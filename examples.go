@@ -0,0 +1,264 @@
+package specgen
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Example is a named example of a request or response body.
+type Example struct {
+	Name        string
+	Summary     string
+	Description string
+	Value       any
+}
+
+// applyExamples documents non-zero Request and Response values as examples. Named examples
+// replace the value example of the same body, as OpenAPI allows only one of them.
+func applyExamples(operation *openapi3.Operation, route Route) error {
+	if err := applyParamExamples(operation, route.Request); err != nil {
+		return err
+	}
+
+	if operation.RequestBody != nil && operation.RequestBody.RequestBody != nil {
+		content := operation.RequestBody.RequestBody.Content
+		for contentType, mediaType := range content {
+			tags := []string{"json"}
+			if isFormContentType(contentType) {
+				tags = formTags
+			}
+			if err := setExamples(&mediaType, contentType, route.Request, route.RequestExamples, tags); err != nil {
+				return fmt.Errorf("request: %w", err)
+			}
+			content[contentType] = mediaType
+		}
+	}
+
+	for _, response := range route.Responses {
+		responseOrRef, ok := operation.Responses.MapOfResponseOrRefValues[strconv.Itoa(response.StatusCode)]
+		if !ok || responseOrRef.Response == nil {
+			continue
+		}
+
+		contentType := mediaTypeOf(response.ContentType)
+		if contentType == "" {
+			contentType = mimeJSON
+		}
+		mediaType, ok := responseOrRef.Response.Content[contentType]
+		if !ok {
+			continue
+		}
+		if err := setExamples(&mediaType, contentType, response.Response, response.Examples, []string{"json"}); err != nil {
+			return fmt.Errorf("response %d: %w", response.StatusCode, err)
+		}
+		responseOrRef.Response.Content[contentType] = mediaType
+	}
+
+	return nil
+}
+
+func setExamples(mediaType *openapi3.MediaType, contentType string, value any, examples []Example, tags []string) error {
+	if len(examples) == 0 {
+		example, err := exampleValue(value, contentType, tags)
+		if err != nil || example == nil {
+			return err
+		}
+		mediaType.Example = &example
+		return nil
+	}
+
+	mediaType.Examples = make(map[string]openapi3.ExampleOrRef, len(examples))
+	for _, example := range examples {
+		v, err := exampleValue(example.Value, contentType, tags)
+		if err != nil {
+			return fmt.Errorf("example %q: %w", example.Name, err)
+		}
+
+		e := openapi3.Example{}
+		if example.Summary != "" {
+			e.WithSummary(example.Summary)
+		}
+		if example.Description != "" {
+			e.WithDescription(example.Description)
+		}
+		if v != nil {
+			e.WithValue(v)
+		}
+		mediaType.Examples[example.Name] = openapi3.ExampleOrRef{Example: &e}
+	}
+	return nil
+}
+
+// exampleValue returns the JSON representation of a non-zero value. Struct values only
+// contribute fields with one of the body tags, like the body schema: other fields are
+// parameters or headers. Raw bodies only accept string examples.
+func exampleValue(value any, contentType string, tags []string) (any, error) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.IsZero() {
+		return nil, nil
+	}
+
+	if !isJSONContentType(contentType) && !isFormContentType(contentType) {
+		if v.Kind() == reflect.String {
+			return v.String(), nil
+		}
+		return nil, nil
+	}
+
+	example, err := bodyValue(v, tags)
+	if body, ok := example.(map[string]any); ok && len(body) == 0 && v.Kind() == reflect.Struct {
+		return nil, err
+	}
+	return example, err
+}
+
+// bodyValue encodes v as JSON, keeping only tagged fields of structs. Nested structs are
+// reflected from their json tags.
+func bodyValue(v reflect.Value, tags []string) (any, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Kind() == reflect.Struct && !implementsMarshaler(v.Type()):
+		body := make(map[string]any)
+		var err error
+		walkTaggedValues(v, tags, func(name string, omitEmpty bool, field reflect.Value) {
+			if err != nil || (field.IsZero() && (omitEmpty || nilable(field.Kind()))) {
+				return
+			}
+			body[name], err = bodyValue(field, []string{"json"})
+		})
+		return body, err
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			item, err := bodyValue(v.Index(i), []string{"json"})
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return jsonValue(v.Interface())
+	}
+}
+
+func implementsMarshaler(typ reflect.Type) bool {
+	marshaler := reflect.TypeFor[json.Marshaler]()
+	text := reflect.TypeFor[encoding.TextMarshaler]()
+	ptr := reflect.PointerTo(typ)
+	return typ.Implements(marshaler) || typ.Implements(text) || ptr.Implements(marshaler) || ptr.Implements(text)
+}
+
+func applyParamExamples(operation *openapi3.Operation, request any) error {
+	v := reflect.ValueOf(request)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for _, paramOrRef := range operation.Parameters {
+		param := paramOrRef.Parameter
+		if param == nil {
+			continue
+		}
+
+		var (
+			example any
+			err     error
+		)
+		walkTaggedValues(v, []string{string(param.In)}, func(name string, _ bool, field reflect.Value) {
+			if name == param.Name && !field.IsZero() {
+				example, err = jsonValue(field.Interface())
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("parameter %q: %w", param.Name, err)
+		}
+		if example != nil {
+			param.WithExample(example)
+		}
+	}
+	return nil
+}
+
+// walkTaggedValues calls fn for struct fields tagged with one of tags, flattening untagged embedded structs.
+func walkTaggedValues(v reflect.Value, tags []string, fn func(name string, omitEmpty bool, field reflect.Value)) {
+	walkFields(v.Type(), tags, func(_ reflect.Type, field reflect.StructField) {
+		tagged := ExtractStructFieldTags(field, tags)
+		if len(tagged) == 0 || !field.IsExported() {
+			return
+		}
+
+		// fails for fields of nil embedded pointers, which have no value
+		value, err := v.FieldByIndexErr(field.Index)
+		name := tagName(tagged[0].Value)
+		if err != nil || name == "-" || name == "" || !value.CanInterface() {
+			return
+		}
+		fn(name, strings.Contains(tagged[0].Value, ",omitempty"), value)
+	})
+}
+
+func jsonValue(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode example: %w", err)
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to decode example: %w", err)
+	}
+	return v, nil
+}
+
+func nilable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+func exampleErrors(route Route) []string {
+	var problems []string
+
+	check := func(subject string, examples []Example) {
+		names := make(map[string]bool)
+		for _, example := range examples {
+			if example.Name == "" {
+				problems = append(problems, subject+" example name is required")
+			} else if names[example.Name] {
+				problems = append(problems, fmt.Sprintf("duplicate %s example %q", subject, example.Name))
+			}
+			names[example.Name] = true
+		}
+	}
+
+	check("request", route.RequestExamples)
+	for _, response := range route.Responses {
+		check(fmt.Sprintf("response %d", response.StatusCode), response.Examples)
+	}
+
+	return problems
+}
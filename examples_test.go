@@ -0,0 +1,172 @@
+package specgen_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type CreateOrderRequest struct {
+	TenantID string   `path:"tenant"`
+	DryRun   bool     `query:"dryRun"`
+	Items    []string `json:"items"`
+	Note     string   `json:"note,omitempty"`
+	Coupon   *string  `json:"coupon"`
+}
+
+type OrderResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type OrderLine struct {
+	SKU      string `json:"sku"`
+	Internal string
+}
+
+type NoteResponse struct {
+	Note     string      `json:"note"`
+	Mode     string      // not tagged, so not part of the body schema
+	ETag     string      `header:"ETag"`
+	Lines    []OrderLine `json:"lines"`
+	Assignee *OrderLine  `json:"assignee"`
+}
+
+func TestGenerateOpenAPISpec_Examples(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/tenants/{tenant}/orders",
+			Method:  "POST",
+			Request: CreateOrderRequest{TenantID: "acme", DryRun: true, Items: []string{"sku-1"}},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 201, Response: OrderResponse{ID: "ord_1", Status: "pending"}},
+				{StatusCode: 200, Response: "id,status\nord_1,pending\n", ContentType: "text/csv"},
+				{
+					StatusCode: 409,
+					Response:   ErrorResponse{},
+					Examples: []specgen.Example{
+						{Name: "duplicate", Summary: "Duplicate order", Value: ErrorResponse{Message: "Order exists", Code: "DUPLICATE"}},
+						{Name: "closed", Description: "The tenant no longer accepts orders", Value: ErrorResponse{Code: "CLOSED"}},
+					},
+				},
+				{StatusCode: 400, Response: ErrorResponse{}},
+				{StatusCode: 202, Response: NoteResponse{Note: "x", Mode: "fast", ETag: `"v1"`, Lines: []OrderLine{{SKU: "sku-1", Internal: "y"}}}},
+			},
+		},
+		{
+			Path:    "/tenants/{tenant}/orders/bulk",
+			Method:  "POST",
+			Request: CreateOrderRequest{},
+			RequestExamples: []specgen.Example{
+				{Name: "single", Value: CreateOrderRequest{TenantID: "ignored", Items: []string{"sku-1"}, Note: "gift"}},
+			},
+			Responses: []specgen.RouteResponse{{StatusCode: 204}},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	type mediaType struct {
+		Example  any `yaml:"example"`
+		Examples map[string]struct {
+			Summary     string `yaml:"summary"`
+			Description string `yaml:"description"`
+			Value       any    `yaml:"value"`
+		} `yaml:"examples"`
+	}
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name    string `yaml:"name"`
+				Example any    `yaml:"example"`
+			} `yaml:"parameters"`
+			RequestBody struct {
+				Content map[string]mediaType `yaml:"content"`
+			} `yaml:"requestBody"`
+			Responses map[string]struct {
+				Content map[string]mediaType `yaml:"content"`
+			} `yaml:"responses"`
+		} `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	create := spec.Paths["/tenants/{tenant}/orders"]["post"]
+	params := make(map[string]any)
+	for _, param := range create.Parameters {
+		params[param.Name] = param.Example
+	}
+	if want := map[string]any{"tenant": "acme", "dryRun": true}; !reflect.DeepEqual(params, want) {
+		t.Errorf("Expected parameter examples %v, got %v", want, params)
+	}
+
+	body := create.RequestBody.Content["application/json"].Example
+	if want := map[string]any{"items": []any{"sku-1"}}; !reflect.DeepEqual(body, want) {
+		t.Errorf("Expected request body example %v, got %v", want, body)
+	}
+
+	created := create.Responses["201"].Content["application/json"].Example
+	if want := map[string]any{"id": "ord_1", "status": "pending"}; !reflect.DeepEqual(created, want) {
+		t.Errorf("Expected response example %v, got %v", want, created)
+	}
+	note := create.Responses["202"].Content["application/json"].Example
+	if want := map[string]any{"note": "x", "lines": []any{map[string]any{"sku": "sku-1"}}}; !reflect.DeepEqual(note, want) {
+		t.Errorf("Expected only body fields in the response example %v, got %v", want, note)
+	}
+	if csv := create.Responses["200"].Content["text/csv"].Example; csv != "id,status\nord_1,pending\n" {
+		t.Errorf("Expected CSV example, got %v", csv)
+	}
+	if example := create.Responses["400"].Content["application/json"].Example; example != nil {
+		t.Errorf("Expected no example for a zero value, got %v", example)
+	}
+
+	conflict := create.Responses["409"].Content["application/json"]
+	if conflict.Example != nil || len(conflict.Examples) != 2 {
+		t.Fatalf("Expected only named examples, got %+v", conflict)
+	}
+	if duplicate := conflict.Examples["duplicate"]; duplicate.Summary != "Duplicate order" ||
+		!reflect.DeepEqual(duplicate.Value, map[string]any{"message": "Order exists", "code": "DUPLICATE"}) {
+		t.Errorf("Unexpected duplicate example: %+v", duplicate)
+	}
+	if closed := conflict.Examples["closed"]; closed.Description != "The tenant no longer accepts orders" {
+		t.Errorf("Unexpected closed example: %+v", closed)
+	}
+
+	bulk := spec.Paths["/tenants/{tenant}/orders/bulk"]["post"].RequestBody.Content["application/json"]
+	if want := map[string]any{"items": []any{"sku-1"}, "note": "gift"}; !reflect.DeepEqual(bulk.Examples["single"].Value, want) {
+		t.Errorf("Expected named request example %v, got %+v", want, bulk.Examples)
+	}
+}
+
+func TestValidateRoutes_ExampleNames(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:            "/orders",
+			Method:          "POST",
+			Request:         CreateOrderRequest{},
+			RequestExamples: []specgen.Example{{Value: CreateOrderRequest{}}},
+			Responses: []specgen.RouteResponse{{
+				StatusCode: 409,
+				Response:   ErrorResponse{},
+				Examples:   []specgen.Example{{Name: "duplicate"}, {Name: "duplicate"}},
+			}},
+		},
+	}
+
+	err := specgen.ValidateRoutes(routes)
+	if err == nil {
+		t.Fatal("Expected validation error, but got nil")
+	}
+	for _, message := range []string{"request example name is required", `duplicate response 409 example "duplicate"`} {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected error containing %q, got %v", message, err)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package specgen
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Server is an API base URL. The URL may contain {variables} declared in Variables,
+// e.g. "https://{region}.api.example.com/{version}".
+type Server struct {
+	URL         string
+	Description string
+	Variables   map[string]ServerVariable
+}
+
+// ServerVariable is substituted into a server URL. Default is required and must be
+// one of Enum when Enum is set.
+type ServerVariable struct {
+	Default     string
+	Enum        []string
+	Description string
+}
+
+type Contact struct {
+	Name  string
+	URL   string
+	Email string
+}
+
+// License describes the API license. OpenAPI 3.0 has no SPDX identifier field, so an
+// Identifier without URL links to the SPDX license page instead.
+type License struct {
+	Name       string
+	Identifier string
+	URL        string
+}
+
+func (s Server) toOpenAPI() (openapi3.Server, error) {
+	server := openapi3.Server{URL: s.URL}
+	if s.URL == "" {
+		return server, fmt.Errorf("url is required")
+	}
+	if s.Description != "" {
+		server.WithDescription(s.Description)
+	}
+
+	used := PathParams(s.URL)
+	for _, name := range used {
+		if _, ok := s.Variables[name]; !ok {
+			return server, fmt.Errorf("variable %q is not declared", name)
+		}
+	}
+
+	// sorted so that the reported error does not depend on map iteration order
+	for _, name := range slices.Sorted(maps.Keys(s.Variables)) {
+		v := s.Variables[name]
+		if !slices.Contains(used, name) {
+			return server, fmt.Errorf("variable %q is not used in the url", name)
+		}
+		if v.Default == "" {
+			return server, fmt.Errorf("default of variable %q is required", name)
+		}
+		if len(v.Enum) > 0 && !slices.Contains(v.Enum, v.Default) {
+			return server, fmt.Errorf("default %q of variable %q is not one of %v", v.Default, name, v.Enum)
+		}
+
+		variable := openapi3.ServerVariable{Default: v.Default, Enum: v.Enum}
+		if v.Description != "" {
+			variable.WithDescription(v.Description)
+		}
+		server.WithVariablesItem(name, variable)
+	}
+
+	return server, nil
+}
+
+func (c Contact) toOpenAPI() openapi3.Contact {
+	contact := openapi3.Contact{}
+	if c.Name != "" {
+		contact.WithName(c.Name)
+	}
+	if c.URL != "" {
+		contact.WithURL(c.URL)
+	}
+	if c.Email != "" {
+		contact.WithEmail(c.Email)
+	}
+	return contact
+}
+
+func (l License) toOpenAPI() (openapi3.License, error) {
+	license := openapi3.License{Name: l.Name}
+	if l.Name == "" {
+		return license, fmt.Errorf("license name is required")
+	}
+
+	switch {
+	case l.URL != "":
+		license.WithURL(l.URL)
+	case l.Identifier != "":
+		license.WithURL("https://spdx.org/licenses/" + l.Identifier + ".html")
+	}
+
+	return license, nil
+}
+
+// setDocumentInfo applies the document-level fields of the config to the spec.
+func setDocumentInfo(spec *openapi3.Spec, config SpecConfig) error {
+	if config.Title != nil {
+		spec.Info.WithTitle(*config.Title)
+	}
+	if config.Description != nil {
+		spec.Info.WithDescription(*config.Description)
+	}
+	if config.Version != nil {
+		spec.Info.WithVersion(*config.Version)
+	}
+	if config.TermsOfService != nil {
+		spec.Info.WithTermsOfService(*config.TermsOfService)
+	}
+	if config.Contact != nil {
+		spec.Info.WithContact(config.Contact.toOpenAPI())
+	}
+	if config.License != nil {
+		license, err := config.License.toOpenAPI()
+		if err != nil {
+			return err
+		}
+		spec.Info.WithLicense(license)
+	}
+
+	for _, s := range config.Servers {
+		server, err := s.toOpenAPI()
+		if err != nil {
+			return fmt.Errorf("invalid server %q: %w", s.URL, err)
+		}
+		spec.Servers = append(spec.Servers, server)
+	}
+
+	if config.ExternalDocs != nil {
+		spec.WithExternalDocs(config.ExternalDocs.toOpenAPI())
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(config.Extensions)) {
+		if !strings.HasPrefix(key, "x-") {
+			return fmt.Errorf("extension %q must start with x-", key)
+		}
+		spec.WithMapOfAnythingItem(key, config.Extensions[key])
+	}
+
+	return nil
+}
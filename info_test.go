@@ -0,0 +1,119 @@
+package specgen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+func healthRoutes() []specgen.Route {
+	return []specgen.Route{
+		{
+			Path:      "/health",
+			Method:    "GET",
+			Responses: []specgen.RouteResponse{{StatusCode: 204, Response: nil}},
+		},
+	}
+}
+
+func TestGenerateOpenAPISpec_DocumentInfo(t *testing.T) {
+	terms := "https://example.com/terms"
+	config := specgen.SpecConfig{
+		TermsOfService: &terms,
+		Contact:        &specgen.Contact{Name: "API Team", Email: "api@example.com"},
+		License:        &specgen.License{Name: "Apache 2.0", Identifier: "Apache-2.0"},
+		Servers: []specgen.Server{
+			{
+				URL:         "https://{region}.api.example.com/{version}",
+				Description: "Production",
+				Variables: map[string]specgen.ServerVariable{
+					"region":  {Default: "eu", Enum: []string{"eu", "us"}},
+					"version": {Default: "v1"},
+				},
+			},
+			{URL: "http://localhost:8080"},
+		},
+		ExternalDocs: &specgen.ExternalDocs{URL: "https://docs.example.com", Description: "Guides"},
+		Extensions:   map[string]any{"x-audience": "external"},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(config, specgen.FormatYAML, healthRoutes())
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Info struct {
+			TermsOfService string            `yaml:"termsOfService"`
+			Contact        map[string]string `yaml:"contact"`
+			License        map[string]string `yaml:"license"`
+		} `yaml:"info"`
+		Servers []struct {
+			URL       string `yaml:"url"`
+			Variables map[string]struct {
+				Default string   `yaml:"default"`
+				Enum    []string `yaml:"enum"`
+			} `yaml:"variables"`
+		} `yaml:"servers"`
+		ExternalDocs map[string]string `yaml:"externalDocs"`
+		Audience     string            `yaml:"x-audience"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	if spec.Info.TermsOfService != terms {
+		t.Errorf("Unexpected termsOfService: %q", spec.Info.TermsOfService)
+	}
+	if spec.Info.Contact["name"] != "API Team" || spec.Info.Contact["email"] != "api@example.com" {
+		t.Errorf("Unexpected contact: %v", spec.Info.Contact)
+	}
+	if spec.Info.License["name"] != "Apache 2.0" || spec.Info.License["url"] != "https://spdx.org/licenses/Apache-2.0.html" {
+		t.Errorf("Expected the SPDX identifier to become the license URL, got: %v", spec.Info.License)
+	}
+	if len(spec.Servers) != 2 {
+		t.Fatalf("Expected 2 servers, got: %+v", spec.Servers)
+	}
+	if region := spec.Servers[0].Variables["region"]; region.Default != "eu" || len(region.Enum) != 2 {
+		t.Errorf("Unexpected region variable: %+v", region)
+	}
+	if spec.ExternalDocs["url"] != "https://docs.example.com" {
+		t.Errorf("Unexpected externalDocs: %v", spec.ExternalDocs)
+	}
+	if spec.Audience != "external" {
+		t.Errorf("Expected root extension x-audience, got: %q", spec.Audience)
+	}
+}
+
+func TestGenerateOpenAPISpec_DocumentInfoErrors(t *testing.T) {
+	for want, config := range map[string]specgen.SpecConfig{
+		`variable "region" is not declared`: {Servers: []specgen.Server{{URL: "https://{region}.example.com"}}},
+		`variable "port" is not used`: {Servers: []specgen.Server{{
+			URL:       "https://example.com",
+			Variables: map[string]specgen.ServerVariable{"port": {Default: "443"}},
+		}}},
+		`default "ap" of variable "region" is not one of [eu us]`: {Servers: []specgen.Server{{
+			URL:       "https://{region}.example.com",
+			Variables: map[string]specgen.ServerVariable{"region": {Default: "ap", Enum: []string{"eu", "us"}}},
+		}}},
+		`default of variable "region" is required`: {Servers: []specgen.Server{{
+			URL:       "https://{region}.example.com",
+			Variables: map[string]specgen.ServerVariable{"region": {}},
+		}}},
+		// the first variable in name order is reported
+		`variable "a" is not used`: {Servers: []specgen.Server{{
+			URL:       "https://example.com",
+			Variables: map[string]specgen.ServerVariable{"c": {Default: "1"}, "a": {Default: "1"}, "b": {Default: "1"}},
+		}}},
+		"license name is required": {License: &specgen.License{Identifier: "MIT"}},
+		// the first invalid extension in key order is reported
+		`extension "audience" must start`: {Extensions: map[string]any{"x-team": "core", "zone": "eu", "audience": "external", "tier": 1}},
+	} {
+		_, err := specgen.GenerateOpenAPISpecBytes(config, specgen.FormatYAML, healthRoutes())
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error containing %q, got: %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package specgen
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var paramTagKeys = []string{string(openapi.InPath), string(openapi.InQuery), string(openapi.InHeader), string(openapi.InCookie)}
+
+var pathParamPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// PathParams returns the names of the {param} placeholders in a path template.
+func PathParams(path string) []string {
+	matches := pathParamPattern.FindAllStringSubmatch(path, -1)
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+func checkPathParams(route Route) string {
+	declared := make(map[string]bool)
+	for _, structTags := range ExtractStructTags(route.Request, []string{string(openapi.InPath)}) {
+		for _, tag := range structTags.Tags {
+			declared[tagName(tag.Value)] = true
+		}
+	}
+
+	for _, name := range PathParams(route.Path) {
+		if !declared[name] {
+			return fmt.Sprintf("path parameter %q is not backed by a request struct field with `path:\"%s\"` tag", name, name)
+		}
+	}
+
+	return ""
+}
+
+// paramField is a parameter struct field with the struct type declaring it.
+type paramField struct {
+	parent reflect.Type
+	field  reflect.StructField
+}
+
+// paramFields indexes request struct fields by parameter location and name,
+// flattening embedded structs the same way the reflector does.
+func paramFields(structure any) map[openapi.In]map[string]paramField {
+	fields := make(map[openapi.In]map[string]paramField)
+
+	typ := structType(structure)
+	if typ == nil {
+		return fields
+	}
+
+	walkFields(typ, paramTagKeys, func(parent reflect.Type, field reflect.StructField) {
+		for _, tag := range ExtractStructFieldTags(field, paramTagKeys) {
+			in := openapi.In(tag.Key)
+			if fields[in] == nil {
+				fields[in] = make(map[string]paramField)
+			}
+			fields[in][tagName(tag.Value)] = paramField{parent: parent, field: field}
+		}
+	})
+
+	return fields
+}
+
+// applyParamSemantics adjusts reflected parameters of an operation: a `required` rule of the validator config
+// marks a parameter as required, and pointer fields become optional rather than nullable.
+func applyParamSemantics(operation *openapi3.Operation, structure any, config ValidatorConfig) {
+	fields := paramFields(structure)
+
+	for _, paramOrRef := range operation.Parameters {
+		param := paramOrRef.Parameter
+		if param == nil {
+			continue
+		}
+
+		pf, ok := fields[openapi.In(param.In)][param.Name]
+		if !ok {
+			continue
+		}
+		field := pf.field
+
+		if field.Type.Kind() == reflect.Pointer && param.Schema != nil && param.Schema.Schema != nil {
+			param.Schema.Schema.Nullable = nil
+		}
+
+		if param.In != openapi3.ParameterInPath && config.parseField(pf.parent, field).Required {
+			param.WithRequired(true)
+		}
+	}
+}
+
+// updateOperation applies fn to an operation already added to the spec and stores the result.
+func updateOperation(spec *openapi3.Spec, method, path string, fn func(operation *openapi3.Operation)) {
+	pathItem, ok := spec.Paths.MapOfPathItemValues[path]
+	if !ok {
+		return
+	}
+
+	method = strings.ToLower(method)
+	operation, ok := pathItem.MapOfOperationValues[method]
+	if !ok {
+		return
+	}
+
+	fn(&operation)
+	pathItem.MapOfOperationValues[method] = operation
+}
+
+func tagName(value string) string {
+	name, _, _ := strings.Cut(value, ",")
+	return name
+}
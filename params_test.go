@@ -0,0 +1,171 @@
+package specgen_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type ParamSpec struct {
+	Paths map[string]map[string]Operation `yaml:"paths"`
+}
+
+type Operation struct {
+	Parameters  []Parameter          `yaml:"parameters"`
+	RequestBody *RequestBody         `yaml:"requestBody"`
+	Responses   map[string]yaml.Node `yaml:"responses"`
+}
+
+type Parameter struct {
+	Name     string   `yaml:"name"`
+	In       string   `yaml:"in"`
+	Required bool     `yaml:"required"`
+	Style    string   `yaml:"style"`
+	Explode  *bool    `yaml:"explode"`
+	Schema   Property `yaml:"schema"`
+}
+
+type RequestBody struct {
+	Content map[string]struct {
+		Schema yaml.Node `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+func (o Operation) Param(in, name string) *Parameter {
+	for i := range o.Parameters {
+		if o.Parameters[i].In == in && o.Parameters[i].Name == name {
+			return &o.Parameters[i]
+		}
+	}
+	return nil
+}
+
+type UpdateItemRequest struct {
+	ID      int               `path:"id"`
+	Tags    []string          `query:"tags" style:"form" explode:"false"`
+	Page    *int              `query:"page"`
+	Search  string            `query:"q" validate:"required"`
+	Filter  map[string]string `query:"filter"`
+	TraceID string            `header:"X-Trace-ID"`
+	Session string            `cookie:"session"`
+	Name    string            `json:"name" validate:"required"`
+}
+
+func TestGenerateOpenAPISpec_Params(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/items/{id}",
+			Method:  "PUT",
+			Request: UpdateItemRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec ParamSpec
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	op := spec.Paths["/items/{id}"]["put"]
+
+	if p := op.Param("path", "id"); p == nil || !p.Required {
+		t.Error("Expected required path parameter 'id'")
+	}
+
+	tags := op.Param("query", "tags")
+	if tags == nil {
+		t.Fatal("Expected query parameter 'tags'")
+	}
+	if tags.Style != "form" || tags.Explode == nil || *tags.Explode {
+		t.Errorf("Expected tags to have style form and explode false, got style=%q explode=%v", tags.Style, tags.Explode)
+	}
+	if tags.Schema.Type == nil || *tags.Schema.Type != "array" {
+		t.Error("Expected tags schema to be an array")
+	}
+
+	page := op.Param("query", "page")
+	if page == nil {
+		t.Fatal("Expected query parameter 'page'")
+	}
+	if page.Required {
+		t.Error("Pointer query parameter 'page' should be optional")
+	}
+	if page.Schema.Nullable != nil {
+		t.Error("Pointer query parameter 'page' should not be nullable")
+	}
+
+	if q := op.Param("query", "q"); q == nil || !q.Required {
+		t.Error("Expected query parameter 'q' to be required by validator")
+	}
+
+	if filter := op.Param("query", "filter"); filter == nil || filter.Style != "deepObject" {
+		t.Error("Expected map query parameter 'filter' to use deepObject style")
+	}
+
+	if op.Param("header", "X-Trace-ID") == nil {
+		t.Error("Expected header parameter 'X-Trace-ID'")
+	}
+	if op.Param("cookie", "session") == nil {
+		t.Error("Expected cookie parameter 'session'")
+	}
+
+	if op.RequestBody == nil {
+		t.Fatal("Expected JSON request body")
+	}
+	if _, ok := op.RequestBody.Content["application/json"]; !ok {
+		t.Error("Expected application/json request body")
+	}
+	if len(op.Parameters) != 7 {
+		t.Errorf("Expected 7 parameters, got %d", len(op.Parameters))
+	}
+}
+
+func TestGenerateOpenAPISpec_MissingPathParam(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:   "/users/{userId}/posts/{postId}",
+			Method: "GET",
+			Request: struct {
+				UserID int `path:"userId"`
+			}{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 200, Response: struct{}{}},
+			},
+		},
+	}
+
+	_, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err == nil {
+		t.Fatal("Expected error for undeclared path parameter, but got nil")
+	}
+	if !strings.Contains(err.Error(), "GET /users/{userId}/posts/{postId}") {
+		t.Errorf("Expected error to name the route, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), `"postId"`) {
+		t.Errorf("Expected error to name the missing parameter, got: %v", err)
+	}
+}
+
+func TestPathParams(t *testing.T) {
+	cases := map[string][]string{
+		"/users":                         {},
+		"/users/{id}":                    {"id"},
+		"/users/{userId}/posts/{postId}": {"userId", "postId"},
+	}
+
+	for path, expected := range cases {
+		if got := specgen.PathParams(path); !reflect.DeepEqual(got, expected) {
+			t.Errorf("PathParams(%q): expected %v, got %v", path, expected, got)
+		}
+	}
+}
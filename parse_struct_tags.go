@@ -25,32 +25,56 @@ type StructTags struct {
 }
 
 func ExtractStructTags(structure any, tagKeys []string) []StructTags {
-	val := reflect.ValueOf(structure)
-	if val.Kind() == reflect.Pointer {
-		val = val.Elem()
-	}
-
-	if val.Kind() != reflect.Struct {
+	typ := structType(structure)
+	if typ == nil {
 		return nil
 	}
 
-	typ := val.Type()
+	return extractTypeTags(typ, tagKeys)
+}
 
+// extractTypeTags walks the type rather than the value, so unexported and nil pointer embedded structs are included.
+func extractTypeTags(typ reflect.Type, tagKeys []string) []StructTags {
 	structTags := make([]StructTags, 0)
 
+	walkFields(typ, tagKeys, func(_ reflect.Type, field reflect.StructField) {
+		structTags = append(structTags, StructTags{Name: field.Name, Tags: ExtractStructFieldTags(field, tagKeys)})
+	})
+
+	return structTags
+}
+
+// walkFields calls fn with every field of typ and the struct type declaring it. Embedded
+// structs without one of tagKeys are flattened like the reflector does, field.Index is
+// then the index sequence from typ.
+func walkFields(typ reflect.Type, tagKeys []string, fn func(parent reflect.Type, field reflect.StructField)) {
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
 
-		// Extract embedded structs
-		if field.Anonymous {
-			embeddedStructTags := ExtractStructTags(val.Field(i).Interface(), tagKeys)
-			structTags = append(structTags, embeddedStructTags...)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && fieldType.Kind() == reflect.Struct && len(ExtractStructFieldTags(field, tagKeys)) == 0 {
+			walkFields(fieldType, tagKeys, func(parent reflect.Type, embedded reflect.StructField) {
+				embedded.Index = append([]int{i}, embedded.Index...)
+				fn(parent, embedded)
+			})
 			continue
 		}
 
-		fieldTags := ExtractStructFieldTags(field, tagKeys)
-		structTags = append(structTags, StructTags{Name: field.Name, Tags: fieldTags})
+		fn(typ, field)
 	}
+}
 
-	return structTags
+// structType returns the struct type of structure, dereferencing pointers, or nil.
+func structType(structure any) reflect.Type {
+	typ := reflect.TypeOf(structure)
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil
+	}
+	return typ
 }
@@ -1,6 +1,7 @@
 package specgen_test
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -103,3 +104,82 @@ func TestExtractStructTags(t *testing.T) {
 		}
 	}
 }
+
+type unexportedParams struct {
+	ID string `path:"id"`
+}
+
+type PointerParams struct {
+	Slug string `path:"slug"`
+}
+
+func TestExtractStructTags_EmbeddedTypes(t *testing.T) {
+	request := struct {
+		unexportedParams
+		*PointerParams
+		Name string `json:"name"`
+	}{}
+
+	result := specgen.ExtractStructTags(request, []string{"path"})
+
+	paths := make(map[string]string)
+	for _, field := range result {
+		for _, tag := range field.Tags {
+			paths[field.Name] = tag.Value
+		}
+	}
+	if paths["ID"] != "id" || paths["Slug"] != "slug" {
+		t.Errorf("Expected path tags of unexported and nil pointer embedded structs, got %v", paths)
+	}
+
+	routes := []specgen.Route{{
+		Path:      "/items/{id}/{slug}",
+		Method:    "PUT",
+		Request:   request,
+		Responses: []specgen.RouteResponse{{StatusCode: 204}},
+	}}
+	if _, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes); err != nil {
+		t.Errorf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+}
+
+type TaggedEmbeddedRequest struct {
+	EmbeddedStruct `json:"meta"`
+	Name           string `json:"name"`
+}
+
+func TestExtractStructTags_TaggedEmbeddedStruct(t *testing.T) {
+	result := specgen.ExtractStructTags(TaggedEmbeddedRequest{}, []string{"json"})
+	if len(result) != 2 || result[0].Name != "EmbeddedStruct" || result[0].Tags[0].Value != "meta" {
+		t.Fatalf("Expected a tagged embedded struct to be a single field, got %v", result)
+	}
+
+	// the example follows the same fields as the schema
+	routes := []specgen.Route{{
+		Path:      "/items",
+		Method:    "POST",
+		Request:   TaggedEmbeddedRequest{EmbeddedStruct: EmbeddedStruct{EmbeddedTag: "x"}, Name: "item"},
+		Responses: []specgen.RouteResponse{{StatusCode: 204}},
+	}}
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatJSON, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Content map[string]struct {
+					Example map[string]any `json:"example"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+	example := spec.Paths["/items"]["post"].RequestBody.Content["application/json"].Example
+	if meta, ok := example["meta"].(map[string]any); !ok || meta["embedded_tag"] != "x" || example["name"] != "item" {
+		t.Errorf("Expected example with nested meta object, got %v", example)
+	}
+}
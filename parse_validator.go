@@ -1,6 +1,7 @@
 package specgen
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 
@@ -8,7 +9,26 @@ import (
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
+// ParseValidatorV10 registers the validator interceptor on the reflector. Call it once
+// per reflector, not once per route.
+//
+// Deprecated: the structure argument is ignored. Use RegisterValidatorV10 instead.
 func ParseValidatorV10(reflector *openapi3.Reflector, structure any) {
+	RegisterValidatorV10(reflector)
+}
+
+// RegisterValidatorV10 maps `validate` tags to schema constraints for every structure
+// reflected afterwards. Every call adds another interceptor, so call it once per
+// reflector; GenerateOpenAPISpec and SpecBuilder register it on a fresh reflector.
+func RegisterValidatorV10(reflector *openapi3.Reflector) {
+	RegisterValidatorV10WithConfig(reflector, ValidatorConfig{})
+}
+
+// RegisterValidatorV10WithConfig is RegisterValidatorV10 with custom validators,
+// handling of unknown tags and alternative tag dialects.
+func RegisterValidatorV10WithConfig(reflector *openapi3.Reflector, config ValidatorConfig) {
+	warned := make(map[string]bool)
+
 	reflector.DefaultOptions = append(reflector.DefaultOptions,
 		jsonschema.InterceptProp(
 			// Property-level (field-level) validation
@@ -19,128 +39,356 @@ func ParseValidatorV10(reflector *openapi3.Reflector, structure any) {
 
 				field := params.Field
 
-				validationTagKey := "validate"
-				validationInfo := ParseValidatorV10Tag(field.Tag.Get(validationTagKey))
+				validationInfo := config.parseField(params.ParentSchema.ReflectType, field)
 
 				// required
-				if validationInfo.Required {
-					requiredFields := params.ParentSchema.Required
-					requiredFields = append(requiredFields, params.Name)
-					params.ParentSchema.WithRequired(requiredFields...)
+				if validationInfo.Required && !slices.Contains(params.ParentSchema.Required, params.Name) {
+					params.ParentSchema.WithRequired(append(params.ParentSchema.Required, params.Name)...)
 				}
 
-				// enum
-				if len(validationInfo.OneOf) > 0 {
-					enumValues := make([]any, len(validationInfo.OneOf))
-					for i, v := range validationInfo.OneOf {
-						enumValues[i] = v
-					}
-					params.PropertySchema.Enum = enumValues
+				propertyTag := params.Context.PropertyNameTag
+				if propertyTag == "" {
+					propertyTag = "json"
 				}
 
-				// format
-				if validationInfo.Format != "" {
-					format := validationInfo.Format
-					params.PropertySchema.Format = &format
+				// cross-field requirements
+				if len(validationInfo.Conditions) > 0 {
+					applyConditionalRules(params.ParentSchema, params.Name, validationInfo.Conditions, propertyTag)
 				}
 
-				// String validators: min, max, len → minLength, maxLength
-				if params.PropertySchema.HasType(jsonschema.String) {
-					if validationInfo.Len != nil {
-						// len=X sets both minLength and maxLength to the same value
-						length := *validationInfo.Len
-						params.PropertySchema.MinLength = length
-						params.PropertySchema.MaxLength = &length
-					} else {
-						if validationInfo.Min != nil {
-							minLen := int64(*validationInfo.Min)
-							params.PropertySchema.MinLength = minLen
-						}
-						if validationInfo.Max != nil {
-							maxLen := int64(*validationInfo.Max)
-							params.PropertySchema.MaxLength = &maxLen
-						}
-					}
+				// cross-field comparisons
+				if len(validationInfo.FieldComparisons) > 0 {
+					applyFieldComparisons(params.PropertySchema, params.ParentSchema, validationInfo.FieldComparisons, propertyTag)
 				}
 
-				// Number validators: min/gte → minimum, max/lte → maximum, gt → minimum+exclusiveMinimum, lt → maximum+exclusiveMaximum
-				if params.PropertySchema.HasType(jsonschema.Number) || params.PropertySchema.HasType(jsonschema.Integer) {
-					// Handle minimum (from min or gte)
-					if validationInfo.Min != nil {
-						minVal := *validationInfo.Min
-						params.PropertySchema.Minimum = &minVal
-					} else if validationInfo.Gte != nil {
-						gteVal := *validationInfo.Gte
-						params.PropertySchema.Minimum = &gteVal
-					}
-					// Handle gt (exclusive minimum)
-					if validationInfo.Gt != nil {
-						gtVal := *validationInfo.Gt
-						params.PropertySchema.Minimum = &gtVal
-						params.PropertySchema.ExclusiveMinimum = &gtVal
-					}
-
-					// Handle maximum (from max or lte)
-					if validationInfo.Max != nil {
-						maxVal := *validationInfo.Max
-						params.PropertySchema.Maximum = &maxVal
-					} else if validationInfo.Lte != nil {
-						lteVal := *validationInfo.Lte
-						params.PropertySchema.Maximum = &lteVal
-					}
-					// Handle lt (exclusive maximum)
-					if validationInfo.Lt != nil {
-						ltVal := *validationInfo.Lt
-						params.PropertySchema.Maximum = &ltVal
-						params.PropertySchema.ExclusiveMaximum = &ltVal
+				err := applyValidationInfo(params.PropertySchema, validationInfo, func(schema *jsonschema.Schema, unknown []string) error {
+					field := params.Field.Name
+					if parent := params.ParentSchema.ReflectType; parent != nil {
+						field = parent.Name() + "." + field
 					}
+					return config.applyUnknown(schema, unknown, field, warned)
+				})
+				if err != nil {
+					return err
 				}
 
-				// Array validators: min → minItems, max → maxItems
-				if params.PropertySchema.HasType(jsonschema.Array) {
-					if validationInfo.Min != nil {
-						minItems := int64(*validationInfo.Min)
-						params.PropertySchema.MinItems = minItems
-					}
-					if validationInfo.Max != nil {
-						maxItems := int64(*validationInfo.Max)
-						params.PropertySchema.MaxItems = &maxItems
-					}
+				// unique=Field on slices of structs
+				if validationInfo.UniqueBy != "" {
+					applyUniqueBy(params.PropertySchema, field.Type, validationInfo.UniqueBy, propertyTag)
 				}
 
+				// zero value and pointer semantics
+				applyPresenceSemantics(params.PropertySchema, validationInfo, field.Type)
+
 				return nil
 			},
 		),
 	)
 }
 
+// unknownTagsFunc applies rules left in ValidationInfo.Unknown to a schema.
+type unknownTagsFunc func(schema *jsonschema.Schema, unknown []string) error
+
+// applyValidationInfo sets the schema constraints described by info, excluding
+// `required` which belongs to the parent schema.
+func applyValidationInfo(schema *jsonschema.Schema, info ValidationInfo, applyUnknown unknownTagsFunc) error {
+	// enum, converted to the property's JSON type
+	if len(info.OneOf) > 0 {
+		enumValues := make([]any, len(info.OneOf))
+		for i, v := range info.OneOf {
+			enumValues[i] = enumValue(schema, v)
+		}
+		schema.Enum = enumValues
+	}
+
+	// eq/ne compare the value of scalars, collections are handled with the length rules.
+	// OpenAPI 3.0 has no const, so eq is a single value enum.
+	if !schema.HasType(jsonschema.Array) && !schema.HasType(jsonschema.Object) {
+		if info.Eq != nil {
+			schema.Enum = []any{enumValue(schema, *info.Eq)}
+		}
+		if info.Ne != nil {
+			addNot(schema, (&jsonschema.Schema{}).WithEnum(enumValue(schema, *info.Ne)).ToSchemaOrBool())
+		}
+	}
+
+	// multipleof
+	if info.MultipleOf != nil && (schema.HasType(jsonschema.Number) || schema.HasType(jsonschema.Integer)) {
+		multipleOf := *info.MultipleOf
+		schema.MultipleOf = &multipleOf
+	}
+
+	// format
+	if info.Format != "" {
+		format := info.Format
+		schema.Format = &format
+	}
+	if info.DatetimeLayout != "" {
+		schema.WithExtraPropertiesItem("x-datetime-layout", info.DatetimeLayout)
+	}
+
+	// String validators: min, max, len → minLength, maxLength
+	if schema.HasType(jsonschema.String) {
+		minLen, maxLen := lengthBounds(info)
+		if minLen != nil {
+			schema.MinLength = *minLen
+		}
+		if maxLen != nil {
+			schema.MaxLength = maxLen
+		}
+	}
+
+	// String content validators: the first pattern goes into `pattern`, the rest into allOf
+	if schema.HasType(jsonschema.String) {
+		applyPatterns(schema, info)
+	}
+
+	// Number validators: min/gte → minimum, max/lte → maximum, gt → minimum+exclusiveMinimum, lt → maximum+exclusiveMaximum
+	if schema.HasType(jsonschema.Number) || schema.HasType(jsonschema.Integer) {
+		// Handle minimum (from min or gte)
+		if info.Min != nil {
+			minVal := *info.Min
+			schema.Minimum = &minVal
+		} else if info.Gte != nil {
+			gteVal := *info.Gte
+			schema.Minimum = &gteVal
+		}
+		// Handle gt (exclusive minimum)
+		if info.Gt != nil {
+			gtVal := *info.Gt
+			schema.Minimum = &gtVal
+			schema.ExclusiveMinimum = &gtVal
+		}
+
+		// Handle maximum (from max or lte)
+		if info.Max != nil {
+			maxVal := *info.Max
+			schema.Maximum = &maxVal
+		} else if info.Lte != nil {
+			lteVal := *info.Lte
+			schema.Maximum = &lteVal
+		}
+		// Handle lt (exclusive maximum)
+		if info.Lt != nil {
+			ltVal := *info.Lt
+			schema.Maximum = &ltVal
+			schema.ExclusiveMaximum = &ltVal
+		}
+	}
+
+	// Array and map validators: length rules → minItems/maxItems or minProperties/maxProperties
+	if schema.HasType(jsonschema.Array) || schema.HasType(jsonschema.Object) {
+		applyCollectionRules(schema, info)
+	}
+
+	// Collection element rules after `dive`
+	if info.Dive != nil || info.Keys != nil {
+		if err := applyDiveInfo(schema, info, applyUnknown); err != nil {
+			return err
+		}
+	}
+
+	// Custom or unknown validators
+	if len(info.Unknown) > 0 && applyUnknown != nil {
+		return applyUnknown(schema, info.Unknown)
+	}
+
+	return nil
+}
+
+func applyPatterns(schema *jsonschema.Schema, info ValidationInfo) {
+	for i, pattern := range info.Patterns {
+		if i == 0 && schema.Pattern == nil {
+			schema.WithPattern(pattern)
+			continue
+		}
+		schema.AllOf = append(schema.AllOf, patternSchema(pattern))
+	}
+
+	switch len(info.NotPatterns) {
+	case 0:
+	case 1:
+		addNot(schema, patternSchema(info.NotPatterns[0]))
+	default:
+		anyOf := make([]jsonschema.SchemaOrBool, 0, len(info.NotPatterns))
+		for _, pattern := range info.NotPatterns {
+			anyOf = append(anyOf, patternSchema(pattern))
+		}
+		addNot(schema, (&jsonschema.Schema{}).WithAnyOf(anyOf...).ToSchemaOrBool())
+	}
+}
+
+// addNot excludes values matching not. A schema has a single not, so further exclusions
+// are added to allOf instead of replacing it.
+func addNot(schema *jsonschema.Schema, not jsonschema.SchemaOrBool) {
+	if schema.Not == nil {
+		schema.WithNot(not)
+		return
+	}
+	schema.AllOf = append(schema.AllOf, (&jsonschema.Schema{}).WithNot(not).ToSchemaOrBool())
+}
+
+func patternSchema(pattern string) jsonschema.SchemaOrBool {
+	return (&jsonschema.Schema{}).WithPattern(pattern).ToSchemaOrBool()
+}
+
+// enumValue converts a validator parameter to the JSON type of the schema.
+func enumValue(schema *jsonschema.Schema, value string) any {
+	switch {
+	case schema.HasType(jsonschema.Integer):
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+		if v, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return v
+		}
+	case schema.HasType(jsonschema.Number):
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case schema.HasType(jsonschema.Boolean):
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
+
+// applyDiveInfo applies rules after `dive` to array items or map values, and rules
+// between `keys` and `endkeys` to map keys. OpenAPI 3.0 has no propertyNames keyword,
+// so key constraints are emitted as the x-propertyNames extension.
+func applyDiveInfo(schema *jsonschema.Schema, info ValidationInfo, applyUnknown unknownTagsFunc) error {
+	if info.Dive != nil {
+		if schema.HasType(jsonschema.Array) && schema.Items != nil {
+			if err := applyValidationInfoToSchemaOrBool(schema.Items.SchemaOrBool, *info.Dive, applyUnknown); err != nil {
+				return err
+			}
+		}
+		if schema.HasType(jsonschema.Object) {
+			if err := applyValidationInfoToSchemaOrBool(schema.AdditionalProperties, *info.Dive, applyUnknown); err != nil {
+				return err
+			}
+		}
+	}
+
+	if info.Keys != nil && schema.HasType(jsonschema.Object) {
+		keySchema := jsonschema.Schema{}
+		keySchema.AddType(jsonschema.String)
+		if err := applyValidationInfo(&keySchema, *info.Keys, applyUnknown); err != nil {
+			return err
+		}
+		schema.WithExtraPropertiesItem("x-propertyNames", keySchema)
+	}
+
+	return nil
+}
+
+func applyValidationInfoToSchemaOrBool(schemaOrBool *jsonschema.SchemaOrBool, info ValidationInfo, applyUnknown unknownTagsFunc) error {
+	// Referenced schemas are shared between properties and cannot be constrained in place.
+	if schemaOrBool == nil || schemaOrBool.TypeObject == nil || schemaOrBool.TypeObject.Ref != nil {
+		return nil
+	}
+	return applyValidationInfo(schemaOrBool.TypeObject, info, applyUnknown)
+}
+
 type ValidationInfo struct {
-	Required bool
-	Format   string // email, uri, uuid, date-time
-	OneOf    []string
-	Min      *float64
-	Max      *float64
-	Len      *int64
-	Gt       *float64
-	Lt       *float64
-	Gte      *float64
-	Lte      *float64
+	Required  bool
+	OmitEmpty bool
+	OmitNil   bool
+	Format    string // see ValidatorFormats
+	OneOf     []string
+	Min       *float64
+	Max       *float64
+	Len       *int64
+	Gt        *float64
+	Lt        *float64
+	Gte       *float64
+	Lte       *float64
+
+	// Eq and Ne compare the value of scalars and the length of collections
+	Eq *string
+	Ne *string
+	// MultipleOf is set by the multipleof custom rule (numbers only)
+	MultipleOf *float64
+
+	// Unique requires unique slice items or map values, UniqueBy names the struct
+	// field that must be unique within a slice (unique=Field)
+	Unique   bool
+	UniqueBy string
+
+	// Conditions are cross-field requirements applied to the parent schema
+	Conditions []ConditionalRule
+	// FieldComparisons compare the field with another field, e.g. gtfield=StartDate
+	FieldComparisons []FieldComparison
+
+	// Unknown holds rules that have no built-in mapping, e.g. custom validators
+	Unknown []string
+
+	// DatetimeLayout is a datetime=layout without a matching OpenAPI format
+	DatetimeLayout string
+
+	// Patterns must all match, NotPatterns must not match (string fields only)
+	Patterns    []string
+	NotPatterns []string
+
+	// Dive holds the rules after `dive`, applied to array items or map values.
+	Dive *ValidationInfo
+	// Keys holds the rules between `keys` and `endkeys`, applied to map keys.
+	Keys *ValidationInfo
 }
 
 func ParseValidatorV10Tag(validateTag string) ValidationInfo {
-	info := ValidationInfo{}
 	if validateTag == "" {
-		return info
+		return ValidationInfo{}
 	}
 
-	parts := strings.SplitSeq(validateTag, ",")
-	for part := range parts {
+	return parseValidatorV10Rules(strings.Split(validateTag, ","))
+}
+
+func parseValidatorV10Rules(parts []string) ValidationInfo {
+	info := ValidationInfo{}
+
+	for i, part := range parts {
 		part = strings.TrimSpace(part)
 
-		// Check for required
-		if part == "required" {
+		// Everything after dive applies to collection elements
+		if part == "dive" {
+			rest := parts[i+1:]
+			if len(rest) > 0 && strings.TrimSpace(rest[0]) == "keys" {
+				end := slices.IndexFunc(rest, func(p string) bool { return strings.TrimSpace(p) == "endkeys" })
+				if end < 0 {
+					end = len(rest)
+				}
+				keys := parseValidatorV10Rules(rest[1:end])
+				info.Keys = &keys
+				rest = rest[min(end+1, len(rest)):]
+			}
+			dive := parseValidatorV10Rules(rest)
+			info.Dive = &dive
+			break
+		}
+
+		// Check for required, omitempty and omitnil
+		switch part {
+		case "required":
 			info.Required = true
 			continue
+		case "omitempty":
+			info.OmitEmpty = true
+			continue
+		case "omitnil":
+			info.OmitNil = true
+			continue
+		}
+
+		// Check for conditional required/excluded validators
+		if parseConditionalRule(&info, part) {
+			continue
+		}
+
+		// Check for field comparison validators
+		if parseFieldComparison(&info, part) {
+			continue
 		}
 
 		// Check for oneof
@@ -149,19 +397,13 @@ func ParseValidatorV10Tag(validateTag string) ValidationInfo {
 			continue
 		}
 
-		// Check for format validators
-		switch part {
-		case "email":
-			info.Format = "email"
+		// Check for string content validators
+		if parseStringPattern(&info, part) {
 			continue
-		case "url":
-			info.Format = "uri"
-			continue
-		case "uuid":
-			info.Format = "uuid"
-			continue
-		case "datetime":
-			info.Format = "date-time"
+		}
+
+		// Check for format validators
+		if parseFormat(&info, part) {
 			continue
 		}
 
@@ -214,6 +456,38 @@ func ParseValidatorV10Tag(validateTag string) ValidationInfo {
 			}
 			continue
 		}
+		// Check for eq and ne validators
+		if eqStr, ok := strings.CutPrefix(part, "eq="); ok {
+			info.Eq = &eqStr
+			continue
+		}
+		if neStr, ok := strings.CutPrefix(part, "ne="); ok {
+			info.Ne = &neStr
+			continue
+		}
+		// Check for unique validator
+		if part == "unique" {
+			info.Unique = true
+			continue
+		}
+		if uniqueBy, ok := strings.CutPrefix(part, "unique="); ok {
+			info.UniqueBy = uniqueBy
+			continue
+		}
+		// Check for multipleof, a common custom validator
+		if multipleOfStr, ok := strings.CutPrefix(part, "multipleof="); ok {
+			if val, err := strconv.ParseFloat(multipleOfStr, 64); err == nil && val > 0 {
+				info.MultipleOf = &val
+			}
+			continue
+		}
+
+		// Rules without schema meaning
+		if noSchemaRules[part] {
+			continue
+		}
+
+		info.Unknown = append(info.Unknown, part)
 	}
 
 	return info
@@ -0,0 +1,444 @@
+package specgen_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type RequiredTwiceRequest struct {
+	Name  string `json:"name" required:"true" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func TestGenerateOpenAPISpec_RequiredNotDuplicated(t *testing.T) {
+	routes := make([]specgen.Route, 0, 20)
+	for i := range 20 {
+		routes = append(routes, specgen.Route{
+			Path:    fmt.Sprintf("/resources/%d", i),
+			Method:  "POST",
+			Request: RequiredTwiceRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 201, Response: struct{}{}},
+			},
+		})
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec OpenAPISpec
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	schema, ok := Schema{}, false
+	for name, s := range spec.Components.Schemas {
+		if strings.Contains(name, "RequiredTwiceRequest") {
+			schema, ok = s, true
+			break
+		}
+	}
+	if !ok {
+		t.Fatal("RequiredTwiceRequest schema not found")
+	}
+	if len(schema.Required) != 2 {
+		t.Errorf("Expected required [name email] without duplicates, got: %v", schema.Required)
+	}
+}
+
+func TestSpecBuilder_RegistersValidatorOnce(t *testing.T) {
+	options := func(count int) int {
+		builder := specgen.NewSpecBuilder(specgen.SpecConfig{})
+		for i := range count {
+			builder.AddRoute(specgen.Route{
+				Path:      fmt.Sprintf("/resources/%d", i),
+				Method:    "POST",
+				Request:   RequiredTwiceRequest{},
+				Responses: []specgen.RouteResponse{{StatusCode: 204}},
+			})
+		}
+		reflector, err := builder.Reflector()
+		if err != nil {
+			t.Fatalf("Reflector failed: %v", err)
+		}
+		return len(reflector.DefaultOptions)
+	}
+
+	if one, many := options(1), options(20); one != many {
+		t.Errorf("Expected reflector options not to grow with routes, got %d for 1 route and %d for 20", one, many)
+	}
+}
+
+func BenchmarkGenerateOpenAPISpec_ManyRoutes(b *testing.B) {
+	routes := make([]specgen.Route, 0, 400)
+	for i := range 400 {
+		routes = append(routes, specgen.Route{
+			Path:    fmt.Sprintf("/resources/%d/users", i),
+			Method:  "POST",
+			Request: CreateUserWithValidateRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 201, Response: UserResponse{}},
+				{StatusCode: 400, Response: ErrorResponse{}},
+			},
+		})
+	}
+
+	for b.Loop() {
+		if _, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes); err != nil {
+			b.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+		}
+	}
+}
+
+type DiveRequest struct {
+	Emails   []string          `json:"emails" validate:"required,min=1,max=5,dive,email,max=64"`
+	Quotas   map[string]int    `json:"quotas" validate:"dive,keys,uuid,endkeys,min=1,max=100"`
+	Labels   map[string]string `json:"labels" validate:"dive,keys,min=2,endkeys,required"`
+	Matrix   [][]string        `json:"matrix" validate:"max=3,dive,max=4,dive,oneof=a b"`
+	Channels []string          `json:"channels" validate:"dive,oneof=email sms"`
+}
+
+func TestParseValidatorV10Tag_Dive(t *testing.T) {
+	info := specgen.ParseValidatorV10Tag("required,min=1,dive,keys,uuid,endkeys,email")
+
+	if !info.Required || info.Min == nil || *info.Min != 1 {
+		t.Errorf("Expected outer rules required,min=1, got: %+v", info)
+	}
+	if info.Format != "" {
+		t.Errorf("Expected no format on the collection itself, got: %s", info.Format)
+	}
+	if info.Keys == nil || info.Keys.Format != "uuid" {
+		t.Errorf("Expected key format uuid, got: %+v", info.Keys)
+	}
+	if info.Dive == nil || info.Dive.Format != "email" {
+		t.Errorf("Expected element format email, got: %+v", info.Dive)
+	}
+}
+
+func TestGenerateOpenAPISpec_ValidatorDive(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/notifications",
+			Method:  "POST",
+			Request: DiveRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Property             `yaml:",inline"`
+					AdditionalProperties *Property `yaml:"additionalProperties"`
+					PropertyNames        *Property `yaml:"x-propertyNames"`
+				} `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	schema, ok := spec.Components.Schemas["GoSpecgenTestDiveRequest"]
+	if !ok {
+		t.Fatalf("DiveRequest schema not found")
+	}
+
+	emails := schema.Properties["emails"]
+	if emails.MinItems == nil || *emails.MinItems != 1 || emails.MaxItems == nil || *emails.MaxItems != 5 {
+		t.Errorf("Expected emails minItems 1 and maxItems 5, got %v %v", emails.MinItems, emails.MaxItems)
+	}
+	if emails.Format != nil || emails.MaxLength != nil {
+		t.Error("Element rules should not be applied to the array itself")
+	}
+	if emails.Items == nil || emails.Items.Format == nil || *emails.Items.Format != "email" {
+		t.Error("Expected emails items to have format email")
+	} else if emails.Items.MaxLength == nil || *emails.Items.MaxLength != 64 {
+		t.Error("Expected emails items to have maxLength 64")
+	}
+
+	quotas := schema.Properties["quotas"]
+	if quotas.AdditionalProperties == nil || quotas.AdditionalProperties.Minimum == nil || *quotas.AdditionalProperties.Minimum != 1 {
+		t.Error("Expected quotas values to have minimum 1")
+	} else if quotas.AdditionalProperties.Maximum == nil || *quotas.AdditionalProperties.Maximum != 100 {
+		t.Error("Expected quotas values to have maximum 100")
+	}
+	if quotas.PropertyNames == nil || quotas.PropertyNames.Format == nil || *quotas.PropertyNames.Format != "uuid" {
+		t.Error("Expected quotas keys to have format uuid")
+	}
+
+	labels := schema.Properties["labels"]
+	if labels.PropertyNames == nil || labels.PropertyNames.MinLength == nil || *labels.PropertyNames.MinLength != 2 {
+		t.Error("Expected labels keys to have minLength 2")
+	}
+
+	matrix := schema.Properties["matrix"]
+	if matrix.MaxItems == nil || *matrix.MaxItems != 3 {
+		t.Error("Expected matrix maxItems 3")
+	}
+	if matrix.Items == nil || matrix.Items.MaxItems == nil || *matrix.Items.MaxItems != 4 {
+		t.Fatal("Expected matrix rows maxItems 4")
+	}
+	if matrix.Items.Items == nil || len(matrix.Items.Items.Enum) != 2 {
+		t.Error("Expected matrix cells enum [a b]")
+	}
+
+	channels := schema.Properties["channels"]
+	if len(channels.Enum) != 0 {
+		t.Error("Element enum should not be applied to the array itself")
+	}
+	if channels.Items == nil || len(channels.Items.Enum) != 2 {
+		t.Error("Expected channels items enum [email sms]")
+	}
+}
+
+type PatternRequest struct {
+	Phone    string `json:"phone" validate:"required,e164"`
+	Slug     string `json:"slug" validate:"lowercase,alphanum"`
+	SKU      string `json:"sku" validate:"startswith=SKU-,endswith=.v1"`
+	Username string `json:"username" validate:"excludes=admin"`
+	Comment  string `json:"comment" validate:"excludesall=<>,excludes=0x2C"`
+	Count    int    `json:"count" validate:"numeric"`
+}
+
+type PatternSchema struct {
+	Pattern string          `yaml:"pattern"`
+	AllOf   []PatternSchema `yaml:"allOf"`
+	AnyOf   []PatternSchema `yaml:"anyOf"`
+	Not     *PatternSchema  `yaml:"not"`
+}
+
+func TestParseValidatorV10Tag_Patterns(t *testing.T) {
+	for name := range map[string]bool{
+		"alpha": true, "alphanum": true, "numeric": true, "hexadecimal": true, "lowercase": true,
+		"uppercase": true, "e164": true, "ascii": true, "base64": true, "hexcolor": true,
+		"startswith=a.b": true, "contains=(x)": true, "containsany=]-^": true, "excludes=y": true,
+	} {
+		info := specgen.ParseValidatorV10Tag(name)
+		patterns := append(info.Patterns, info.NotPatterns...)
+		if len(patterns) != 1 {
+			t.Errorf("%s: expected one pattern, got %v", name, patterns)
+			continue
+		}
+		if _, err := regexp.Compile(patterns[0]); err != nil {
+			t.Errorf("%s: pattern %q does not compile: %v", name, patterns[0], err)
+		}
+	}
+
+	info := specgen.ParseValidatorV10Tag("startswith=a.b")
+	if !regexp.MustCompile(info.Patterns[0]).MatchString("a.bc") || regexp.MustCompile(info.Patterns[0]).MatchString("axbc") {
+		t.Errorf("startswith pattern should match the literal prefix, got %q", info.Patterns[0])
+	}
+}
+
+func TestGenerateOpenAPISpec_ValidatorPatterns(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/items",
+			Method:  "POST",
+			Request: PatternRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]PatternSchema `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	props := spec.Components.Schemas["GoSpecgenTestPatternRequest"].Properties
+
+	if props["phone"].Pattern != `^\+[1-9]?[0-9]{7,14}$` {
+		t.Errorf("Unexpected phone pattern: %q", props["phone"].Pattern)
+	}
+
+	slug := props["slug"]
+	if slug.Pattern != `^[^A-Z]+$` || len(slug.AllOf) != 1 || slug.AllOf[0].Pattern != `^[a-zA-Z0-9]+$` {
+		t.Errorf("Expected slug to combine lowercase and alphanum patterns, got: %+v", slug)
+	}
+
+	sku := props["sku"]
+	if sku.Pattern != `^SKU-` || len(sku.AllOf) != 1 || sku.AllOf[0].Pattern != `\.v1$` {
+		t.Errorf("Expected sku startswith and endswith patterns, got: %+v", sku)
+	}
+
+	if username := props["username"]; username.Not == nil || username.Not.Pattern != "admin" {
+		t.Errorf("Expected username not pattern 'admin', got: %+v", username)
+	}
+
+	comment := props["comment"]
+	if comment.Not == nil || len(comment.Not.AnyOf) != 2 {
+		t.Fatalf("Expected comment not anyOf two patterns, got: %+v", comment)
+	}
+	if comment.Not.AnyOf[0].Pattern != "[<>]" || comment.Not.AnyOf[1].Pattern != "," {
+		t.Errorf("Unexpected comment not patterns: %+v", comment.Not.AnyOf)
+	}
+
+	if props["count"].Pattern != "" {
+		t.Error("String patterns should not be applied to numeric fields")
+	}
+}
+
+type NumericRequest struct {
+	Priority int      `json:"priority" validate:"oneof=1 2 3"`
+	Retries  uint8    `json:"retries" validate:"oneof=0 5"`
+	Ratio    float64  `json:"ratio" validate:"oneof=0.5 1.5"`
+	Level    string   `json:"level" validate:"oneof=low high"`
+	Version  int      `json:"version" validate:"eq=2"`
+	Status   string   `json:"status" validate:"ne=deleted"`
+	Amount   float64  `json:"amount" validate:"multipleof=0.01"`
+	Step     int      `json:"step" validate:"min=0,multipleof=5"`
+	Pair     []string `json:"pair" validate:"eq=2"`
+	Codes    []string `json:"codes" validate:"ne=0"`
+	User     string   `json:"user" validate:"ne=root,excludes=admin"`
+	Port     int      `json:"port" validate:"required,ne=5"`
+}
+
+type NumericSchema struct {
+	Type       string          `yaml:"type"`
+	Enum       []any           `yaml:"enum"`
+	MultipleOf *float64        `yaml:"multipleOf"`
+	MinItems   *int64          `yaml:"minItems"`
+	MaxItems   *int64          `yaml:"maxItems"`
+	Pattern    string          `yaml:"pattern"`
+	Not        *NumericSchema  `yaml:"not"`
+	AllOf      []NumericSchema `yaml:"allOf"`
+}
+
+func TestParseValidatorV10Tag_EqNeMultipleOf(t *testing.T) {
+	info := specgen.ParseValidatorV10Tag("eq=1,ne=2,multipleof=0.5,eqfield=Other")
+	if info.Eq == nil || *info.Eq != "1" || info.Ne == nil || *info.Ne != "2" {
+		t.Errorf("Expected eq and ne to be parsed, got: %+v", info)
+	}
+	if info.MultipleOf == nil || *info.MultipleOf != 0.5 {
+		t.Errorf("Expected multipleof 0.5, got: %v", info.MultipleOf)
+	}
+	if len(info.FieldComparisons) != 1 || len(info.Unknown) != 0 {
+		t.Errorf("eqfield should stay a field comparison, got: %+v", info)
+	}
+
+	if info := specgen.ParseValidatorV10Tag("multipleof=0"); info.MultipleOf != nil {
+		t.Errorf("multipleof must be positive, got: %v", *info.MultipleOf)
+	}
+}
+
+func TestGenerateOpenAPISpec_ValidatorNumeric(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/tasks",
+			Method:  "POST",
+			Request: NumericRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]NumericSchema `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	props := spec.Components.Schemas["GoSpecgenTestNumericRequest"].Properties
+
+	enums := map[string]string{
+		"priority": "[1 2 3]",
+		"retries":  "[0 5]",
+		"ratio":    "[0.5 1.5]",
+		"level":    "[low high]",
+		"version":  "[2]",
+	}
+	for name, want := range enums {
+		if got := fmt.Sprint(props[name].Enum); got != want {
+			t.Errorf("%s: expected enum %s, got %s", name, want, got)
+		}
+	}
+	for _, name := range []string{"priority", "retries", "version"} {
+		for _, v := range props[name].Enum {
+			if _, ok := v.(int); !ok {
+				t.Errorf("%s: expected integer enum values, got %T", name, v)
+			}
+		}
+	}
+	for _, v := range props["ratio"].Enum {
+		if _, ok := v.(float64); !ok {
+			t.Errorf("ratio: expected number enum values, got %T", v)
+		}
+	}
+	for _, v := range props["level"].Enum {
+		if _, ok := v.(string); !ok {
+			t.Errorf("level: expected string enum values, got %T", v)
+		}
+	}
+
+	if status := props["status"]; status.Not == nil || fmt.Sprint(status.Not.Enum) != "[deleted]" {
+		t.Errorf("Expected status not enum [deleted], got: %+v", status)
+	}
+
+	if amount := props["amount"]; amount.MultipleOf == nil || *amount.MultipleOf != 0.01 {
+		t.Errorf("Expected amount multipleOf 0.01, got: %+v", amount)
+	}
+	if step := props["step"]; step.MultipleOf == nil || *step.MultipleOf != 5 {
+		t.Errorf("Expected step multipleOf 5, got: %+v", step)
+	}
+
+	pair := props["pair"]
+	if pair.Enum != nil || pair.MinItems == nil || *pair.MinItems != 2 || pair.MaxItems == nil || *pair.MaxItems != 2 {
+		t.Errorf("Expected eq on an array to fix its length, got: %+v", pair)
+	}
+	codes := props["codes"]
+	if codes.Not == nil || codes.Not.MaxItems == nil || *codes.Not.MaxItems != 0 {
+		t.Errorf("Expected ne on an array to exclude its length, got: %+v", codes)
+	}
+
+	// further exclusions are combined in allOf rather than replacing not
+	user := props["user"]
+	if user.Not == nil || fmt.Sprint(user.Not.Enum) != "[root]" ||
+		len(user.AllOf) != 1 || user.AllOf[0].Not == nil || !strings.Contains(user.AllOf[0].Not.Pattern, "admin") {
+		t.Errorf("Expected user to exclude root and admin, got: %+v", user)
+	}
+	port := props["port"]
+	if port.Not == nil || fmt.Sprint(port.Not.Enum) != "[5]" ||
+		len(port.AllOf) != 1 || port.AllOf[0].Not == nil || fmt.Sprint(port.AllOf[0].Not.Enum) != "[0]" {
+		t.Errorf("Expected port to exclude 5 and the zero value, got: %+v", port)
+	}
+}
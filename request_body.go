@@ -0,0 +1,218 @@
+package specgen
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+const (
+	mimeJSON           = "application/json"
+	mimeFormURLEncoded = "application/x-www-form-urlencoded"
+	mimeMultipart      = "multipart/form-data"
+)
+
+var formTags = []string{"formData", "form", "file"}
+
+// PartEncoding describes how a property of a form request body is encoded.
+type PartEncoding struct {
+	ContentType string
+	// Headers is a struct whose `header` tagged fields are documented as part headers.
+	Headers any
+}
+
+// addRequest declares the route request. Fields tagged with `file` are treated like `formData` fields.
+// Requests with a raw content type only contribute parameters, the body is a string.
+func addRequest(op openapi.OperationContext, route Route) {
+	contentType := route.RequestContentType
+	if contentType != "" && !isFormContentType(contentType) && !isJSONContentType(contentType) {
+		if params := paramStructure(route.Request); params != nil {
+			op.AddReqStructure(params, func(cu *openapi.ContentUnit) {
+				cu.ContentType = mimeJSON
+			})
+		}
+		op.AddReqStructure(nil, func(cu *openapi.ContentUnit) {
+			cu.ContentType = contentType
+			cu.Format = rawFormat(contentType)
+		})
+		return
+	}
+
+	files := jsonschema.MakePropertyNameMapping(route.Request, "file")
+	op.AddReqStructure(route.Request, func(cu *openapi.ContentUnit) {
+		cu.SetFieldMapping(openapi.InFormData, files)
+		switch {
+		case isFormContentType(contentType):
+			cu.ContentType = mediaTypeOf(contentType)
+		case contentType != "":
+			cu.ContentType = mimeJSON
+		}
+	})
+}
+
+// paramStructure returns a value of a struct type with only the parameter fields of the
+// request, flattening embedded structs, or nil when there are none.
+func paramStructure(request any) any {
+	typ := structType(request)
+	if typ == nil {
+		return nil
+	}
+
+	var fields []reflect.StructField
+	walkFields(typ, paramTagKeys, func(_ reflect.Type, field reflect.StructField) {
+		isParam := len(ExtractStructFieldTags(field, paramTagKeys)) > 0
+		duplicate := slices.ContainsFunc(fields, func(f reflect.StructField) bool { return f.Name == field.Name })
+		if !isParam || !field.IsExported() || duplicate {
+			return
+		}
+
+		field.Index, field.Offset, field.Anonymous = nil, 0, false
+		fields = append(fields, field)
+	})
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return reflect.New(reflect.StructOf(fields)).Elem().Interface()
+}
+
+// applyRequestBody moves the reflected body to the route content type and adds part encodings.
+func applyRequestBody(operation *openapi3.Operation, contentType string, encoding map[string]openapi3.Encoding) {
+	if operation.RequestBody == nil || operation.RequestBody.RequestBody == nil {
+		return
+	}
+	content := operation.RequestBody.RequestBody.Content
+
+	if isFormContentType(contentType) || (contentType != "" && isJSONContentType(contentType)) {
+		for _, reflected := range []string{mimeJSON, mimeFormURLEncoded, mimeMultipart} {
+			if mediaType, ok := content[reflected]; ok && reflected != contentType {
+				delete(content, reflected)
+				content[contentType] = mediaType
+			}
+		}
+	}
+
+	if len(encoding) == 0 {
+		return
+	}
+	for key, mediaType := range content {
+		if isFormContentType(key) {
+			mediaType.Encoding = encoding
+			content[key] = mediaType
+		}
+	}
+}
+
+// dropFormQueryParams removes the query parameters the reflector derives from `form` fields
+// without a `query` tag, as those fields already belong to the form request body.
+func dropFormQueryParams(operation *openapi3.Operation, structure any) {
+	if operation.RequestBody == nil || operation.RequestBody.RequestBody == nil {
+		return
+	}
+	if !slices.ContainsFunc(slices.Collect(maps.Keys(operation.RequestBody.RequestBody.Content)), isFormContentType) {
+		return
+	}
+
+	formOnly := make(map[string]bool)
+	for _, structTags := range ExtractStructTags(structure, []string{"form", string(openapi.InQuery)}) {
+		if len(structTags.Tags) == 1 && structTags.Tags[0].Key == "form" {
+			formOnly[tagName(structTags.Tags[0].Value)] = true
+		}
+	}
+
+	operation.Parameters = slices.DeleteFunc(operation.Parameters, func(paramOrRef openapi3.ParameterOrRef) bool {
+		param := paramOrRef.Parameter
+		return param != nil && param.In == openapi3.ParameterInQuery && formOnly[param.Name]
+	})
+}
+
+// requestEncoding converts part encodings, reflecting part headers with the spec reflector.
+func requestEncoding(reflector *openapi3.Reflector, parts map[string]PartEncoding) (map[string]openapi3.Encoding, error) {
+	if len(parts) == 0 {
+		return nil, nil
+	}
+
+	encoding := make(map[string]openapi3.Encoding, len(parts))
+	for name, part := range parts {
+		var e openapi3.Encoding
+		if part.ContentType != "" {
+			e.WithContentType(part.ContentType)
+		}
+		if part.Headers != nil {
+			headers, err := reflectHeaders(reflector, part.Headers)
+			if err != nil {
+				return nil, fmt.Errorf("failed to reflect headers of part %q: %w", name, err)
+			}
+			e.WithHeaders(headers)
+		}
+		encoding[name] = e
+	}
+	return encoding, nil
+}
+
+func reflectHeaders(reflector *openapi3.Reflector, structure any) (map[string]openapi3.Header, error) {
+	schema, err := reflector.JSONSchemaReflector().Reflect(structure,
+		func(rc *jsonschema.ReflectContext) {
+			rc.ProcessWithoutTags = false
+		},
+		jsonschema.InlineRefs,
+		jsonschema.PropertyNameTag("header"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]openapi3.Header, len(schema.Properties))
+	for name, property := range schema.Properties {
+		s := openapi3.SchemaOrRef{}
+		s.FromJSONSchema(property)
+
+		header := openapi3.Header{Schema: &s}
+		if property.TypeObject != nil && property.TypeObject.Description != nil {
+			header.WithDescription(*property.TypeObject.Description)
+		}
+		if slices.Contains(schema.Required, name) {
+			header.WithRequired(true)
+		}
+		headers[name] = header
+	}
+	return headers, nil
+}
+
+func requestErrors(route Route) []string {
+	var problems []string
+
+	if route.RequestContentType != "" && len(route.RequestEncoding) > 0 && !isFormContentType(route.RequestContentType) {
+		problems = append(problems, fmt.Sprintf("request encoding requires a form content type, got %s", route.RequestContentType))
+	}
+
+	fields := make(map[string]bool)
+	for _, structTags := range ExtractStructTags(route.Request, formTags) {
+		for _, tag := range structTags.Tags {
+			fields[tagName(tag.Value)] = true
+		}
+	}
+	for name := range route.RequestEncoding {
+		if !fields[name] {
+			problems = append(problems, fmt.Sprintf("request encoding %q does not match a form field of the request", name))
+		}
+	}
+	slices.Sort(problems)
+
+	return problems
+}
+
+func isFormContentType(contentType string) bool {
+	mediaType := mediaTypeOf(contentType)
+	return mediaType == mimeFormURLEncoded || mediaType == mimeMultipart
+}
+
+func mediaTypeOf(contentType string) string {
+	return strings.TrimSpace(strings.Split(contentType, ";")[0])
+}
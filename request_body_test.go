@@ -0,0 +1,199 @@
+package specgen_test
+
+import (
+	"mime/multipart"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type UploadAvatarRequest struct {
+	UserID  int                   `path:"id"`
+	Avatar  *multipart.FileHeader `file:"avatar" validate:"required"`
+	Caption string                `formData:"caption"`
+}
+
+type AvatarPartHeaders struct {
+	Checksum string `header:"X-Checksum" description:"SHA-256 of the part" validate:"required"`
+}
+
+type LoginForm struct {
+	Username string `form:"username"`
+	Password string `form:"password"`
+}
+
+type PatchUserRequest struct {
+	Name *string `json:"name"`
+}
+
+type ImportRequest struct {
+	Dataset string `path:"dataset"`
+	DryRun  bool   `query:"dryRun"`
+	// raw bodies replace body fields
+	Note string `json:"note"`
+}
+
+type requestBodySpec struct {
+	Paths map[string]map[string]struct {
+		Parameters  []map[string]any `yaml:"parameters"`
+		RequestBody struct {
+			Content map[string]struct {
+				Schema   map[string]any `yaml:"schema"`
+				Encoding map[string]struct {
+					ContentType string                    `yaml:"contentType"`
+					Headers     map[string]map[string]any `yaml:"headers"`
+				} `yaml:"encoding"`
+			} `yaml:"content"`
+		} `yaml:"requestBody"`
+	} `yaml:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Type       string                    `yaml:"type"`
+			Format     string                    `yaml:"format"`
+			Properties map[string]map[string]any `yaml:"properties"`
+		} `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+func TestGenerateOpenAPISpec_RequestBodies(t *testing.T) {
+	ok := []specgen.RouteResponse{{StatusCode: 204}}
+	routes := []specgen.Route{
+		{
+			Path:    "/users/{id}/avatar",
+			Method:  "PUT",
+			Request: UploadAvatarRequest{},
+			RequestEncoding: map[string]specgen.PartEncoding{
+				"avatar": {ContentType: "image/png, image/jpeg", Headers: AvatarPartHeaders{}},
+			},
+			Responses: ok,
+		},
+		{Path: "/login", Method: "POST", Request: LoginForm{}, Responses: ok},
+		{Path: "/login/multipart", Method: "POST", Request: LoginForm{}, RequestContentType: "multipart/form-data", Responses: ok},
+		{Path: "/imports/{dataset}", Method: "POST", Request: ImportRequest{}, RequestContentType: "text/csv", Responses: ok},
+		{Path: "/imports/{dataset}/archive", Method: "POST", Request: ImportRequest{}, RequestContentType: "application/zip", Responses: ok},
+		{Path: "/users", Method: "PATCH", Request: PatchUserRequest{}, RequestContentType: "application/merge-patch+json", Responses: ok},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec requestBodySpec
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	contentTypes := func(path, method string) []string {
+		var types []string
+		for contentType := range spec.Paths[path][method].RequestBody.Content {
+			types = append(types, contentType)
+		}
+		return types
+	}
+
+	upload := spec.Paths["/users/{id}/avatar"]["put"]
+	multipartBody, found := upload.RequestBody.Content["multipart/form-data"]
+	if !found || len(upload.RequestBody.Content) != 1 {
+		t.Fatalf("Expected multipart/form-data body, got %v", contentTypes("/users/{id}/avatar", "put"))
+	}
+	if len(upload.Parameters) != 1 || upload.Parameters[0]["name"] != "id" {
+		t.Errorf("Expected path parameter id, got %v", upload.Parameters)
+	}
+	ref, _ := multipartBody.Schema["$ref"].(string)
+	schema := spec.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+	avatarRef, _ := schema.Properties["avatar"]["$ref"].(string)
+	if avatar := spec.Components.Schemas[strings.TrimPrefix(avatarRef, "#/components/schemas/")]; avatar.Type != "string" || avatar.Format != "binary" {
+		t.Errorf("Expected binary avatar property, got %v", schema.Properties)
+	}
+	if _, found := schema.Properties["caption"]; !found {
+		t.Errorf("Expected caption property, got %v", schema.Properties)
+	}
+	encoding := multipartBody.Encoding["avatar"]
+	if encoding.ContentType != "image/png, image/jpeg" {
+		t.Errorf("Expected avatar part content type, got %q", encoding.ContentType)
+	}
+	if checksum := encoding.Headers["X-Checksum"]; checksum["required"] != true || checksum["description"] != "SHA-256 of the part" {
+		t.Errorf("Expected required X-Checksum part header, got %v", encoding.Headers)
+	}
+
+	// `form` fields belong to the form body and are not repeated as query parameters
+	for _, path := range []string{"/login", "/login/multipart"} {
+		if parameters := spec.Paths[path]["post"].Parameters; len(parameters) != 0 {
+			t.Errorf("Expected no parameters for %s, got %v", path, parameters)
+		}
+	}
+
+	expected := map[string]string{
+		"/login":                     "application/x-www-form-urlencoded",
+		"/login/multipart":           "multipart/form-data",
+		"/imports/{dataset}":         "text/csv",
+		"/imports/{dataset}/archive": "application/zip",
+		"/users":                     "application/merge-patch+json",
+	}
+	for path, contentType := range expected {
+		for method, operation := range spec.Paths[path] {
+			if _, found := operation.RequestBody.Content[contentType]; !found || len(operation.RequestBody.Content) != 1 {
+				t.Errorf("Expected %s %s body, got %v", path, contentType, contentTypes(path, method))
+			}
+		}
+	}
+
+	csv := spec.Paths["/imports/{dataset}"]["post"]
+	if s := csv.RequestBody.Content["text/csv"].Schema; s["type"] != "string" || s["format"] != nil {
+		t.Errorf("Expected string CSV body, got %v", s)
+	}
+	var params []string
+	for _, param := range csv.Parameters {
+		params = append(params, param["name"].(string))
+	}
+	if slices.Sort(params); !slices.Equal(params, []string{"dataset", "dryRun"}) {
+		t.Errorf("Expected dataset and dryRun parameters, got %v", csv.Parameters)
+	}
+	if s := spec.Paths["/imports/{dataset}/archive"]["post"].RequestBody.Content["application/zip"].Schema; s["format"] != "binary" {
+		t.Errorf("Expected binary archive body, got %v", s)
+	}
+}
+
+func TestValidateRoutes_RequestEncoding(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/users/{id}/avatar",
+			Method:  "PUT",
+			Request: UploadAvatarRequest{},
+			RequestEncoding: map[string]specgen.PartEncoding{
+				"avatar": {ContentType: "image/png"},
+				"thumb":  {ContentType: "image/png"},
+			},
+			Responses: []specgen.RouteResponse{{StatusCode: 204}},
+		},
+		{
+			Path:               "/imports/{dataset}",
+			Method:             "POST",
+			Request:            ImportRequest{},
+			RequestContentType: "text/csv",
+			RequestEncoding:    map[string]specgen.PartEncoding{"dataset": {ContentType: "text/plain"}},
+			Responses:          []specgen.RouteResponse{{StatusCode: 204}},
+		},
+	}
+
+	err := specgen.ValidateRoutes(routes)
+	if err == nil {
+		t.Fatal("Expected validation error, but got nil")
+	}
+	for _, message := range []string{
+		`request encoding "thumb" does not match a form field`,
+		"request encoding requires a form content type, got text/csv",
+		`request encoding "dataset" does not match a form field`,
+	} {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected error containing %q, got %v", message, err)
+		}
+	}
+	if strings.Contains(err.Error(), `"avatar"`) {
+		t.Errorf("Expected avatar file field to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package specgen
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// addResponses declares route responses on the operation. Bodies are added before headers
+// because the reflector replaces the headers of a status with those of every content unit.
+func addResponses(op openapi.OperationContext, responses []RouteResponse) {
+	for _, response := range responses {
+		structure, format := responseBody(response)
+		op.AddRespStructure(structure, func(cu *openapi.ContentUnit) {
+			cu.HTTPStatus = response.StatusCode
+			cu.ContentType = response.ContentType
+			cu.Format = format
+			cu.Description = response.Description
+		})
+	}
+
+	for _, response := range responses {
+		if response.Headers == nil {
+			continue
+		}
+		op.AddRespStructure(response.Headers, func(cu *openapi.ContentUnit) {
+			cu.HTTPStatus = response.StatusCode
+			cu.Description = response.Description
+		})
+	}
+}
+
+// responseBody returns the structure to reflect and the string format of a raw body.
+// Non-JSON responses without a value or with a []byte value are raw strings.
+func responseBody(response RouteResponse) (any, string) {
+	if isJSONContentType(response.ContentType) {
+		return response.Response, ""
+	}
+	if response.Response == nil {
+		return nil, rawFormat(response.ContentType)
+	}
+	if t := reflect.TypeOf(response.Response); t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		return nil, rawFormat(response.ContentType)
+	}
+	return response.Response, ""
+}
+
+// rawFormat is the string format of a raw request or response body: text/* bodies are
+// plain strings, anything else is binary.
+func rawFormat(contentType string) string {
+	if strings.HasPrefix(mediaTypeOf(contentType), "text/") {
+		return ""
+	}
+	return "binary"
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType := mediaTypeOf(contentType)
+	return mediaType == "" || mediaType == mimeJSON || strings.HasSuffix(mediaType, "+json")
+}
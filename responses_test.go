@@ -0,0 +1,149 @@
+package specgen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type CreatedHeaders struct {
+	Location string `header:"Location" description:"URL of the created report"`
+	ETag     string `header:"ETag"`
+}
+
+type RateLimitHeaders struct {
+	RetryAfter int `header:"Retry-After" description:"Seconds to wait before retrying"`
+}
+
+type Report struct {
+	ID string `json:"id"`
+}
+
+type Problem struct {
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+}
+
+func TestGenerateOpenAPISpec_Responses(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:   "/reports",
+			Method: "POST",
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 201, Response: Report{}, Description: "Report created", Headers: CreatedHeaders{}},
+				{StatusCode: 429, Response: Problem{}, ContentType: "application/problem+json", Headers: RateLimitHeaders{}},
+			},
+		},
+		{
+			Path:   "/reports/{id}",
+			Method: "GET",
+			Request: struct {
+				ID string `path:"id"`
+			}{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 200, Response: Report{}},
+				{StatusCode: 200, Response: "", ContentType: "text/csv"},
+				{StatusCode: 200, Response: []byte{}, ContentType: "application/pdf"},
+				{StatusCode: 200, ContentType: "image/png"},
+				{StatusCode: 200, ContentType: "text/plain; charset=utf-8"},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	type schema struct {
+		Ref    string `yaml:"$ref"`
+		Type   string `yaml:"type"`
+		Format string `yaml:"format"`
+	}
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Description string `yaml:"description"`
+				Headers     map[string]struct {
+					Description string `yaml:"description"`
+					Schema      schema `yaml:"schema"`
+				} `yaml:"headers"`
+				Content map[string]struct {
+					Schema schema `yaml:"schema"`
+				} `yaml:"content"`
+			} `yaml:"responses"`
+		} `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	created := spec.Paths["/reports"]["post"].Responses["201"]
+	if created.Description != "Report created" {
+		t.Errorf("Expected custom description, got %q", created.Description)
+	}
+	if len(created.Headers) != 2 || created.Headers["Location"].Description != "URL of the created report" {
+		t.Errorf("Expected Location and ETag headers, got %+v", created.Headers)
+	}
+	if !strings.HasSuffix(created.Content["application/json"].Schema.Ref, "Report") {
+		t.Errorf("Expected JSON report body, got %+v", created.Content)
+	}
+
+	tooMany := spec.Paths["/reports"]["post"].Responses["429"]
+	if tooMany.Description != "Too Many Requests" {
+		t.Errorf("Expected status text description, got %q", tooMany.Description)
+	}
+	if tooMany.Headers["Retry-After"].Schema.Type != "integer" {
+		t.Errorf("Expected integer Retry-After header, got %+v", tooMany.Headers)
+	}
+	if _, ok := tooMany.Content["application/problem+json"]; !ok || len(tooMany.Content) != 1 {
+		t.Errorf("Expected only application/problem+json content, got %+v", tooMany.Content)
+	}
+
+	ok := spec.Paths["/reports/{id}"]["get"].Responses["200"]
+	expected := map[string]schema{
+		"text/csv":        {Type: "string"},
+		"application/pdf": {Type: "string", Format: "binary"},
+		"image/png":       {Type: "string", Format: "binary"},
+		"text/plain":      {Type: "string"},
+	}
+	if len(ok.Content) != 5 {
+		t.Fatalf("Expected 5 content types, got %+v", ok.Content)
+	}
+	for contentType, want := range expected {
+		if got := ok.Content[contentType].Schema; got != want {
+			t.Errorf("Expected %s schema %+v, got %+v", contentType, want, got)
+		}
+	}
+}
+
+func TestValidateRoutes_ResponseContentTypes(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:   "/reports",
+			Method: "GET",
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 200, Response: Report{}},
+				{StatusCode: 200, Response: Report{}, ContentType: "application/json"},
+				{StatusCode: 200, ContentType: "text/csv", Headers: CreatedHeaders{}},
+				{StatusCode: 200, ContentType: "text/csv", Headers: CreatedHeaders{}},
+			},
+		},
+	}
+
+	err := specgen.ValidateRoutes(routes)
+	if err == nil {
+		t.Fatal("Expected validation error, but got nil")
+	}
+	for _, message := range []string{
+		"duplicate response status code 200 with content type application/json",
+		"duplicate response status code 200 with content type text/csv",
+		"headers of response status code 200 are declared more than once",
+	} {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected error containing %q, got %v", message, err)
+		}
+	}
+}
@@ -7,10 +7,31 @@ type Route struct {
 	Path        string
 	Method      string
 	Request     any
-	Responses   []RouteResponse
+	// RequestContentType overrides the request body media type, e.g. multipart/form-data or text/csv.
+	// Bodies of types other than JSON and forms are documented as strings, binary unless text/*.
+	RequestContentType string
+	// RequestEncoding maps form fields to their part encoding.
+	RequestEncoding map[string]PartEncoding
+	// RequestExamples are named request body examples, used instead of the Request value.
+	RequestExamples []Example
+	Responses       []RouteResponse
+	Security        []SecurityRequirement
 }
 
 type RouteResponse struct {
 	StatusCode int
 	Response   any
+	// ContentType defaults to application/json. Responses of other types with a nil or []byte
+	// Response are documented as strings, binary unless text/*, e.g. file downloads.
+	ContentType string
+	// Description defaults to the status text.
+	Description string
+	// Headers is a struct whose `header` tagged fields are documented as response headers.
+	Headers any
+	// Examples are named examples, used instead of the Response value.
+	Examples []Example
 }
+
+// SecurityRequirement maps security scheme names to the scopes required by a route.
+// Schemes within one requirement must all be satisfied; separate requirements are alternatives.
+type SecurityRequirement map[string][]string
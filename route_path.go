@@ -0,0 +1,57 @@
+package specgen
+
+import "strings"
+
+// PathFromBraces converts chi and gorilla/mux paths to OpenAPI path templates:
+// "{id:[0-9]+}" becomes "{id}" and a trailing "*" becomes "{*}". Parameter names are
+// kept as the router reports them to handlers.
+func PathFromBraces(path string) string {
+	var b strings.Builder
+	depth := 0
+	skip := false
+
+	for _, c := range path {
+		switch {
+		case c == '{':
+			depth++
+			if depth == 1 {
+				skip = false
+				b.WriteRune(c)
+				continue
+			}
+		case c == '}':
+			depth--
+			if depth == 0 {
+				b.WriteRune(c)
+				continue
+			}
+		case c == ':' && depth == 1:
+			skip = true
+		}
+
+		if depth == 0 || !skip {
+			b.WriteRune(c)
+		}
+	}
+
+	converted := b.String()
+	if strings.HasSuffix(converted, "/*") {
+		converted = strings.TrimSuffix(converted, "*") + "{*}"
+	}
+	return converted
+}
+
+// PathFromColons converts gin and echo paths to OpenAPI path templates: ":id" becomes
+// "{id}", "*filepath" becomes "{filepath}" and "*" becomes "{*}".
+func PathFromColons(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "*":
+			segments[i] = "{*}"
+		case strings.HasPrefix(segment, ":"), strings.HasPrefix(segment, "*"):
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
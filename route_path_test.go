@@ -0,0 +1,36 @@
+package specgen_test
+
+import (
+	"testing"
+
+	specgen "github.com/lutfiandri/go-specgen"
+)
+
+func TestPathFromBraces(t *testing.T) {
+	for path, want := range map[string]string{
+		"/users/{id}":                     "/users/{id}",
+		"/users/{id:[0-9]+}":              "/users/{id}",
+		"/codes/{code:[A-Z]{3}}/items":    "/codes/{code}/items",
+		"/files/*":                        "/files/{*}",
+		"/":                               "/",
+		"/orgs/{org}/repos/{repo:[a-z]+}": "/orgs/{org}/repos/{repo}",
+	} {
+		if got := specgen.PathFromBraces(path); got != want {
+			t.Errorf("PathFromBraces(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestPathFromColons(t *testing.T) {
+	for path, want := range map[string]string{
+		"/users/:id":            "/users/{id}",
+		"/users/:id/posts/:pid": "/users/{id}/posts/{pid}",
+		"/static/*filepath":     "/static/{filepath}",
+		"/files/*":              "/files/{*}",
+		"/health":               "/health",
+	} {
+		if got := specgen.PathFromColons(path); got != want {
+			t.Errorf("PathFromColons(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
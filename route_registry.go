@@ -0,0 +1,104 @@
+package specgen
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// RouteRegistry holds Route metadata per handler for router adapters. The adapter walks
+// the router for methods and paths and takes everything else from the registry.
+type RouteRegistry struct {
+	mu     sync.RWMutex
+	routes map[string][]Route
+}
+
+func NewRouteRegistry() *RouteRegistry {
+	return &RouteRegistry{routes: make(map[string][]Route)}
+}
+
+// Document registers route for the handler and returns the handler, so it can wrap the
+// handler where it is passed to the router:
+//
+//	r.Get("/users/{id}", specgen.Document(registry, getUser, specgen.Route{...}))
+func Document[H any](registry *RouteRegistry, handler H, route Route) H {
+	registry.Register(handler, route)
+	return handler
+}
+
+// Register records route for the handler. A non-empty route.Method restricts the
+// metadata to that method, so one handler can be documented once per method.
+//
+// Handlers are identified by HandlerName, and closures returned by the same factory
+// function share one name. Register panics when the handler name is already documented
+// for an overlapping method, rather than replacing the metadata of another handler.
+func (r *RouteRegistry) Register(handler any, route Route) {
+	name := HandlerName(handler)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, registered := range r.routes[name] {
+		if registered.Method == "" || route.Method == "" || strings.EqualFold(registered.Method, route.Method) {
+			panic(fmt.Sprintf("specgen: handler %s is already documented for %s; closures created by the same function share a name, document them with distinct methods or use named handlers",
+				name, methodsOf(registered.Method)))
+		}
+	}
+	r.routes[name] = append(r.routes[name], route)
+}
+
+// Lookup returns the routes registered for the handler, one per documented method.
+func (r *RouteRegistry) Lookup(handler any) []Route {
+	return r.LookupName(HandlerName(handler))
+}
+
+func (r *RouteRegistry) LookupName(name string) []Route {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.routes[name])
+}
+
+// Resolve returns the route registered for the handler name with the method and path
+// found in the router. ok is false for undocumented handlers and other methods.
+func (r *RouteRegistry) Resolve(name, method, path string) (Route, bool) {
+	method = strings.ToUpper(method)
+	if !httpMethods[method] {
+		return Route{}, false
+	}
+
+	for _, route := range r.LookupName(name) {
+		if route.Method != "" && !strings.EqualFold(route.Method, method) {
+			continue
+		}
+
+		route.Method = method
+		route.Path = path
+		return route, true
+	}
+	return Route{}, false
+}
+
+func methodsOf(method string) string {
+	if method == "" {
+		return "all methods"
+	}
+	return strings.ToUpper(method)
+}
+
+// HandlerName identifies a handler the way routers name them: functions by their
+// runtime name, e.g. "main.getUser", pointers by type and address, other values by type.
+func HandlerName(handler any) string {
+	value := reflect.ValueOf(handler)
+	switch value.Kind() {
+	case reflect.Func:
+		if fn := runtime.FuncForPC(value.Pointer()); fn != nil {
+			return fn.Name()
+		}
+	case reflect.Pointer:
+		return fmt.Sprintf("%T(%#x)", handler, value.Pointer())
+	}
+	return fmt.Sprintf("%T", handler)
+}
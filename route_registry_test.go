@@ -0,0 +1,73 @@
+package specgen_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+)
+
+func listAccounts(w http.ResponseWriter, r *http.Request) {}
+
+func TestRouteRegistry_Resolve(t *testing.T) {
+	registry := specgen.NewRouteRegistry()
+	handler := specgen.Document(registry, listAccounts, specgen.Route{
+		Summary: "List accounts",
+		Method:  "GET",
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 200, Response: nil},
+		},
+	})
+
+	// routers wrap functions in their own handler types
+	name := specgen.HandlerName(http.HandlerFunc(handler))
+	if name != "github.com/lutfiandri/go-specgen_test.listAccounts" {
+		t.Errorf("Unexpected handler name: %s", name)
+	}
+
+	route, ok := registry.Resolve(name, "get", "/accounts")
+	if !ok || route.Method != "GET" || route.Path != "/accounts" || route.Summary != "List accounts" {
+		t.Errorf("Expected the documented route with the router's method and path, got: %+v", route)
+	}
+
+	if _, ok := registry.Resolve(name, "POST", "/accounts"); ok {
+		t.Error("Route.Method should restrict the metadata to GET")
+	}
+	if _, ok := registry.Resolve("main.unknown", "GET", "/accounts"); ok {
+		t.Error("Undocumented handlers should not resolve")
+	}
+}
+
+func handlerFor(message string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(message)) }
+}
+
+func TestRouteRegistry_Methods(t *testing.T) {
+	registry := specgen.NewRouteRegistry()
+	specgen.Document(registry, listAccounts, specgen.Route{Summary: "List accounts", Method: "GET"})
+	specgen.Document(registry, listAccounts, specgen.Route{Summary: "Create account", Method: "POST"})
+
+	name := specgen.HandlerName(listAccounts)
+	if routes := registry.LookupName(name); len(routes) != 2 {
+		t.Fatalf("Expected a route per method, got %+v", routes)
+	}
+	for method, summary := range map[string]string{"GET": "List accounts", "POST": "Create account"} {
+		if route, ok := registry.Resolve(name, method, "/accounts"); !ok || route.Summary != summary {
+			t.Errorf("Expected %s to resolve to %q, got %+v", method, summary, route)
+		}
+	}
+}
+
+func TestRouteRegistry_DuplicateName(t *testing.T) {
+	registry := specgen.NewRouteRegistry()
+	specgen.Document(registry, handlerFor("users"), specgen.Route{Summary: "List users"})
+
+	defer func() {
+		message, _ := recover().(string)
+		if !strings.Contains(message, "already documented for all methods") {
+			t.Errorf("Expected a panic for closures sharing a name, got %q", message)
+		}
+	}()
+	specgen.Document(registry, handlerFor("orders"), specgen.Route{Summary: "List orders", Method: "GET"})
+}
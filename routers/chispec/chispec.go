@@ -0,0 +1,33 @@
+// Package chispec builds specgen routes from a chi router.
+package chispec
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lutfiandri/go-specgen"
+)
+
+// Routes walks the router and returns a route for every handler documented in the
+// registry, with the method and path taken from the router.
+func Routes(router chi.Routes, registry *specgen.RouteRegistry) ([]specgen.Route, error) {
+	var routes []specgen.Route
+
+	err := chi.Walk(router, func(method, path string, handler http.Handler, _ ...func(http.Handler) http.Handler) error {
+		// handlers registered with r.With(...) are wrapped in a chain
+		if chain, ok := handler.(*chi.ChainHandler); ok {
+			handler = chain.Endpoint
+		}
+
+		if route, ok := registry.Resolve(specgen.HandlerName(handler), method, specgen.PathFromBraces(path)); ok {
+			routes = append(routes, route)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk chi routes: %w", err)
+	}
+
+	return routes, nil
+}
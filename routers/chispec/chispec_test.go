@@ -0,0 +1,62 @@
+package chispec_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lutfiandri/go-specgen"
+	"github.com/lutfiandri/go-specgen/routers/chispec"
+)
+
+type GetUserRequest struct {
+	ID int `path:"id"`
+}
+
+type UserResponse struct {
+	ID int `json:"id"`
+}
+
+func getUser(w http.ResponseWriter, r *http.Request)     {}
+func listFiles(w http.ResponseWriter, r *http.Request)   {}
+func healthCheck(w http.ResponseWriter, r *http.Request) {}
+
+func TestRoutes(t *testing.T) {
+	registry := specgen.NewRouteRegistry()
+
+	router := chi.NewRouter()
+	router.Get("/health", healthCheck)
+	router.Route("/api", func(r chi.Router) {
+		r.With(func(next http.Handler) http.Handler { return next }).Get("/users/{id:[0-9]+}", specgen.Document(registry, getUser, specgen.Route{
+			Summary: "Get user",
+			Request: GetUserRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 200, Response: UserResponse{}},
+			},
+		}))
+		r.Get("/files/*", specgen.Document(registry, listFiles, specgen.Route{
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		}))
+	})
+
+	routes, err := chispec.Routes(router, registry)
+	if err != nil {
+		t.Fatalf("Routes failed: %v", err)
+	}
+
+	paths := make(map[string]specgen.Route)
+	for _, route := range routes {
+		paths[route.Method+" "+route.Path] = route
+	}
+	if len(paths) != 2 {
+		t.Fatalf("Expected 2 documented routes, got: %v", paths)
+	}
+	if route, ok := paths["GET /api/users/{id}"]; !ok || route.Summary != "Get user" {
+		t.Errorf("Expected GET /api/users/{id} with its summary, got: %v", paths)
+	}
+	if _, ok := paths["GET /api/files/{*}"]; !ok {
+		t.Errorf("Expected wildcard route GET /api/files/{*}, got: %v", paths)
+	}
+}
@@ -0,0 +1,19 @@
+module github.com/lutfiandri/go-specgen/routers/chispec
+
+go 1.25.1
+
+require (
+	github.com/go-chi/chi/v5 v5.3.2
+	github.com/lutfiandri/go-specgen v0.0.0-20261017012158-9a8219974e8d
+)
+
+require (
+	github.com/kr/text v0.2.0 // indirect
+	github.com/swaggest/jsonschema-go v0.3.74 // indirect
+	github.com/swaggest/openapi-go v0.2.60 // indirect
+	github.com/swaggest/refl v1.3.1 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+)
+
+// Only applies when developing in this repository, users get the version required above.
+replace github.com/lutfiandri/go-specgen => ../..
@@ -0,0 +1,20 @@
+// Package echospec builds specgen routes from an echo instance.
+package echospec
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/lutfiandri/go-specgen"
+)
+
+// Routes returns a route for every handler documented in the registry, with the method
+// and path taken from echo. Echo routes are matched by their name, which defaults to
+// the handler name, so renamed routes are not found.
+func Routes(e *echo.Echo, registry *specgen.RouteRegistry) []specgen.Route {
+	var routes []specgen.Route
+	for _, info := range e.Routes() {
+		if route, ok := registry.Resolve(info.Name, info.Method, specgen.PathFromColons(info.Path)); ok {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
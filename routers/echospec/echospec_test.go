@@ -0,0 +1,64 @@
+package echospec_test
+
+import (
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lutfiandri/go-specgen"
+	"github.com/lutfiandri/go-specgen/routers/echospec"
+)
+
+type GetUserRequest struct {
+	ID int `path:"id"`
+}
+
+type UserResponse struct {
+	ID int `json:"id"`
+}
+
+func getUser(c echo.Context) error     { return nil }
+func updateUser(c echo.Context) error  { return nil }
+func healthCheck(c echo.Context) error { return nil }
+
+func TestRoutes(t *testing.T) {
+	registry := specgen.NewRouteRegistry()
+
+	e := echo.New()
+	e.GET("/health", healthCheck)
+	api := e.Group("/api")
+	api.GET("/users/:id", specgen.Document(registry, getUser, specgen.Route{
+		Summary: "Get user",
+		Request: GetUserRequest{},
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 200, Response: UserResponse{}},
+		},
+	}))
+	// Method restricts the metadata to PUT although the handler is mounted for all methods
+	api.Any("/users/:id/profile", specgen.Document(registry, updateUser, specgen.Route{
+		Method:  "PUT",
+		Request: GetUserRequest{},
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 204, Response: nil},
+		},
+	}))
+
+	routes := echospec.Routes(e, registry)
+
+	paths := make(map[string]specgen.Route)
+	for _, route := range routes {
+		paths[route.Method+" "+route.Path] = route
+	}
+	if len(paths) != 2 {
+		t.Fatalf("Expected 2 documented routes, got: %v", paths)
+	}
+	if route, ok := paths["GET /api/users/{id}"]; !ok || route.Summary != "Get user" {
+		t.Errorf("Expected GET /api/users/{id} with its summary, got: %v", paths)
+	}
+	if _, ok := paths["PUT /api/users/{id}/profile"]; !ok {
+		t.Errorf("Expected PUT /api/users/{id}/profile, got: %v", paths)
+	}
+
+	if _, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes); err != nil {
+		t.Errorf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+}
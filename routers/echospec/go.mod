@@ -0,0 +1,28 @@
+module github.com/lutfiandri/go-specgen/routers/echospec
+
+go 1.25.1
+
+require (
+	github.com/labstack/echo/v4 v4.16.0
+	github.com/lutfiandri/go-specgen v0.0.0-20261017012158-9a8219974e8d
+)
+
+require (
+	github.com/kr/text v0.2.0 // indirect
+	github.com/labstack/gommon v0.5.0 // indirect
+	github.com/mattn/go-colorable v0.1.15 // indirect
+	github.com/mattn/go-isatty v0.0.22 // indirect
+	github.com/swaggest/jsonschema-go v0.3.74 // indirect
+	github.com/swaggest/openapi-go v0.2.60 // indirect
+	github.com/swaggest/refl v1.3.1 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.2 // indirect
+	golang.org/x/crypto v0.53.0 // indirect
+	golang.org/x/net v0.56.0 // indirect
+	golang.org/x/sys v0.46.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+)
+
+// Only applies when developing in this repository, users get the version required above.
+replace github.com/lutfiandri/go-specgen => ../..
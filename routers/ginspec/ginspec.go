@@ -0,0 +1,20 @@
+// Package ginspec builds specgen routes from a gin engine.
+package ginspec
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/lutfiandri/go-specgen"
+)
+
+// Routes returns a route for every handler documented in the registry, with the method
+// and path taken from the engine. Gin names a route after its last handler, so that is
+// the handler to document.
+func Routes(engine *gin.Engine, registry *specgen.RouteRegistry) []specgen.Route {
+	var routes []specgen.Route
+	for _, info := range engine.Routes() {
+		if route, ok := registry.Resolve(info.Handler, info.Method, specgen.PathFromColons(info.Path)); ok {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
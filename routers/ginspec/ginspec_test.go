@@ -0,0 +1,58 @@
+package ginspec_test
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lutfiandri/go-specgen"
+	"github.com/lutfiandri/go-specgen/routers/ginspec"
+)
+
+type GetUserRequest struct {
+	ID int `path:"id"`
+}
+
+type UserResponse struct {
+	ID int `json:"id"`
+}
+
+func getUser(c *gin.Context)     {}
+func serveStatic(c *gin.Context) {}
+func healthCheck(c *gin.Context) {}
+
+func TestRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registry := specgen.NewRouteRegistry()
+
+	engine := gin.New()
+	engine.GET("/health", healthCheck)
+	api := engine.Group("/api", func(c *gin.Context) { c.Next() })
+	api.GET("/users/:id", specgen.Document(registry, getUser, specgen.Route{
+		Summary: "Get user",
+		Request: GetUserRequest{},
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 200, Response: UserResponse{}},
+		},
+	}))
+	engine.GET("/static/*filepath", specgen.Document(registry, serveStatic, specgen.Route{
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 200, Response: nil},
+		},
+	}))
+
+	routes := ginspec.Routes(engine, registry)
+
+	paths := make(map[string]specgen.Route)
+	for _, route := range routes {
+		paths[route.Method+" "+route.Path] = route
+	}
+	if len(paths) != 2 {
+		t.Fatalf("Expected 2 documented routes, got: %v", paths)
+	}
+	if route, ok := paths["GET /api/users/{id}"]; !ok || route.Summary != "Get user" {
+		t.Errorf("Expected GET /api/users/{id} with its summary, got: %v", paths)
+	}
+	if _, ok := paths["GET /static/{filepath}"]; !ok {
+		t.Errorf("Expected wildcard route GET /static/{filepath}, got: %v", paths)
+	}
+}
@@ -0,0 +1,51 @@
+// Package muxspec builds specgen routes from a gorilla/mux router.
+package muxspec
+
+import (
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/lutfiandri/go-specgen"
+)
+
+// Routes walks the router and returns a route for every handler documented in the
+// registry, with the methods and path taken from the router. Routes without Methods
+// need a Method in the registry.
+func Routes(router *mux.Router, registry *specgen.RouteRegistry) ([]specgen.Route, error) {
+	var routes []specgen.Route
+
+	err := router.Walk(func(muxRoute *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		handler := muxRoute.GetHandler()
+		if handler == nil {
+			// subrouter
+			return nil
+		}
+
+		template, err := muxRoute.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		path := specgen.PathFromBraces(template)
+		name := specgen.HandlerName(handler)
+
+		methods, err := muxRoute.GetMethods()
+		if err != nil {
+			methods = nil
+			for _, documented := range registry.LookupName(name) {
+				methods = append(methods, documented.Method)
+			}
+		}
+
+		for _, method := range methods {
+			if route, ok := registry.Resolve(name, method, path); ok {
+				routes = append(routes, route)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk mux routes: %w", err)
+	}
+
+	return routes, nil
+}
@@ -0,0 +1,64 @@
+package muxspec_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lutfiandri/go-specgen"
+	"github.com/lutfiandri/go-specgen/routers/muxspec"
+)
+
+type GetUserRequest struct {
+	ID int `path:"id"`
+}
+
+type UserResponse struct {
+	ID int `json:"id"`
+}
+
+type exportHandler struct{}
+
+func (h *exportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func getUser(w http.ResponseWriter, r *http.Request)     {}
+func healthCheck(w http.ResponseWriter, r *http.Request) {}
+
+func TestRoutes(t *testing.T) {
+	registry := specgen.NewRouteRegistry()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/health", healthCheck).Methods("GET")
+	api := router.PathPrefix("/api").Subrouter()
+	api.HandleFunc("/users/{id:[0-9]+}", specgen.Document(registry, getUser, specgen.Route{
+		Summary: "Get user",
+		Request: GetUserRequest{},
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 200, Response: UserResponse{}},
+		},
+	})).Methods("GET", "HEAD")
+	api.Handle("/export", specgen.Document(registry, &exportHandler{}, specgen.Route{
+		Method: "POST",
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 202, Response: nil},
+		},
+	}))
+
+	routes, err := muxspec.Routes(router, registry)
+	if err != nil {
+		t.Fatalf("Routes failed: %v", err)
+	}
+
+	paths := make(map[string]specgen.Route)
+	for _, route := range routes {
+		paths[route.Method+" "+route.Path] = route
+	}
+	if len(paths) != 3 {
+		t.Fatalf("Expected 3 documented routes, got: %v", paths)
+	}
+	for _, key := range []string{"GET /api/users/{id}", "HEAD /api/users/{id}", "POST /api/export"} {
+		if _, ok := paths[key]; !ok {
+			t.Errorf("Expected route %s, got: %v", key, paths)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package specgen
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// SchemaNaming controls the names of component schemas. The zero value keeps the
+// default package-qualified names, e.g. MainCreateUserRequest.
+type SchemaNaming struct {
+	// StripPackage drops the package prefix, e.g. ModelsUser becomes User.
+	StripPackage bool
+	// Prefix is prepended to every name, e.g. a service name.
+	Prefix string
+	// Func names a type. Its result is used as is, an empty result falls back to
+	// StripPackage and Prefix.
+	Func func(t reflect.Type) string
+}
+
+// schemaNamer assigns component schema names and records the first collision, since
+// the reflector would otherwise rename one of the components silently.
+type schemaNamer struct {
+	naming SchemaNaming
+	types  map[string]reflect.Type
+	err    error
+}
+
+func newSchemaNamer(naming SchemaNaming) *schemaNamer {
+	return &schemaNamer{naming: naming, types: make(map[string]reflect.Type)}
+}
+
+func (n *schemaNamer) defName(t reflect.Type, defaultDefName string) string {
+	name := n.name(t, defaultDefName)
+
+	if other, ok := n.types[name]; ok && other != t {
+		if n.err == nil {
+			n.err = fmt.Errorf("schema name %q is used by both %s and %s", name, typeString(other), typeString(t))
+		}
+		return name
+	}
+	n.types[name] = t
+
+	return name
+}
+
+func (n *schemaNamer) name(t reflect.Type, defaultDefName string) string {
+	if n.naming.Func != nil {
+		if name := n.naming.Func(t); name != "" {
+			return name
+		}
+	}
+
+	baseName, typeArgs, generic := strings.Cut(t.Name(), "[")
+
+	name := defaultDefName
+	if n.naming.StripPackage || generic {
+		name = camelName(baseName)
+		if !n.naming.StripPackage && t.PkgPath() != "main" {
+			name = camelName(path.Base(t.PkgPath())) + name
+		}
+	}
+
+	// Type arguments are named without their package, Page[models.User] becomes PageUser
+	if generic {
+		name += camelName(typeArgNames(strings.TrimSuffix(typeArgs, "]")))
+	}
+
+	return n.naming.Prefix + name
+}
+
+// qualifiedIdent matches the import path and package of a type argument,
+// e.g. "github.com/acme/api/models." in "github.com/acme/api/models.User".
+var qualifiedIdent = regexp.MustCompile(`(?:[\w.\-]+/)*[\w\-]+\.`)
+
+func typeArgNames(typeArgs string) string {
+	typeArgs = qualifiedIdent.ReplaceAllString(typeArgs, "")
+	typeArgs = strings.ReplaceAll(typeArgs, "[]", "List ")
+	return strings.ReplaceAll(typeArgs, "*", "")
+}
+
+// camelName joins the alphanumeric parts of s, upper-casing the first letter of each.
+func camelName(s string) string {
+	var b strings.Builder
+	for part := range strings.FieldsFuncSeq(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+func typeString(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
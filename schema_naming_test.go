@@ -0,0 +1,138 @@
+package specgen_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type Account struct {
+	Name string `json:"name"`
+}
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+// ExternalDocs has the same name as specgen.ExternalDocs.
+type ExternalDocs struct {
+	Link string `json:"link"`
+}
+
+type DocsResponse struct {
+	Local   ExternalDocs         `json:"local"`
+	Library specgen.ExternalDocs `json:"library"`
+}
+
+func schemaNames(t *testing.T, naming specgen.SchemaNaming, routes []specgen.Route) map[string]bool {
+	t.Helper()
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{SchemaNaming: naming}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]any `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	names := make(map[string]bool)
+	for name := range spec.Components.Schemas {
+		names[name] = true
+	}
+	return names
+}
+
+func accountRoutes() []specgen.Route {
+	return []specgen.Route{
+		{
+			Path:    "/accounts",
+			Method:  "GET",
+			Request: nil,
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 200, Response: Page[Account]{}},
+			},
+		},
+		{
+			Path:    "/accounts",
+			Method:  "POST",
+			Request: Account{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 201, Response: Account{}},
+			},
+		},
+	}
+}
+
+func TestSchemaNaming_Default(t *testing.T) {
+	names := schemaNames(t, specgen.SchemaNaming{}, accountRoutes())
+
+	for _, name := range []string{"GoSpecgenTestAccount", "GoSpecgenTestPageAccount"} {
+		if !names[name] {
+			t.Errorf("Expected schema %s, got: %v", name, names)
+		}
+	}
+}
+
+func TestSchemaNaming_StripPackageAndPrefix(t *testing.T) {
+	names := schemaNames(t, specgen.SchemaNaming{StripPackage: true, Prefix: "Billing"}, accountRoutes())
+
+	for _, name := range []string{"BillingAccount", "BillingPageAccount"} {
+		if !names[name] {
+			t.Errorf("Expected schema %s, got: %v", name, names)
+		}
+	}
+}
+
+func TestSchemaNaming_Func(t *testing.T) {
+	naming := specgen.SchemaNaming{
+		StripPackage: true,
+		Func: func(t reflect.Type) string {
+			if t == reflect.TypeOf(Account{}) {
+				return "Customer"
+			}
+			return ""
+		},
+	}
+	names := schemaNames(t, naming, accountRoutes())
+
+	if !names["Customer"] || !names["PageAccount"] || len(names) != 2 {
+		t.Errorf("Expected schemas Customer and PageAccount, got: %v", names)
+	}
+}
+
+func TestSchemaNaming_Collision(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/docs",
+			Method:  "GET",
+			Request: nil,
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 200, Response: DocsResponse{}},
+			},
+		},
+	}
+
+	schemaNames(t, specgen.SchemaNaming{}, routes)
+
+	_, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{
+		SchemaNaming: specgen.SchemaNaming{StripPackage: true},
+	}, specgen.FormatYAML, routes)
+	if err == nil {
+		t.Fatal("Expected an error for colliding schema names")
+	}
+	for _, want := range []string{`"ExternalDocs"`, "GET /docs", "go-specgen_test.ExternalDocs", "go-specgen.ExternalDocs"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+}
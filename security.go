@@ -0,0 +1,312 @@
+package specgen
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// SecuritySchemeType is the type of a security scheme.
+type SecuritySchemeType string
+
+const (
+	SecurityAPIKey        SecuritySchemeType = "apiKey"
+	SecurityHTTP          SecuritySchemeType = "http"
+	SecurityOAuth2        SecuritySchemeType = "oauth2"
+	SecurityOpenIDConnect SecuritySchemeType = "openIdConnect"
+	// SecurityMutualTLS was added in OpenAPI 3.1 and cannot be expressed in the generated
+	// OpenAPI 3.0 spec, declaring it fails generation.
+	SecurityMutualTLS SecuritySchemeType = "mutualTLS"
+)
+
+type APIKeyLocation string
+
+const (
+	APIKeyInHeader APIKeyLocation = "header"
+	APIKeyInQuery  APIKeyLocation = "query"
+	APIKeyInCookie APIKeyLocation = "cookie"
+)
+
+// SecurityScheme declares a scheme under components.securitySchemes. Routes refer to
+// it by Name in their SecurityRequirement. The fields used depend on Type, as in the
+// OpenAPI Security Scheme Object.
+type SecurityScheme struct {
+	Name        string
+	Type        SecuritySchemeType
+	Description string
+
+	// apiKey: the header, query or cookie parameter holding the key
+	In        APIKeyLocation
+	ParamName string
+
+	// http: e.g. basic or bearer, with an optional hint such as JWT for bearer tokens
+	Scheme       string
+	BearerFormat string
+
+	// oauth2
+	Flows OAuthFlows
+
+	// openIdConnect
+	OpenIDConnectURL string
+}
+
+type OAuthFlows struct {
+	Implicit          *OAuthFlow
+	Password          *OAuthFlow
+	ClientCredentials *OAuthFlow
+	AuthorizationCode *OAuthFlow
+}
+
+// OAuthFlow holds the URLs used by a flow and its scopes, mapped to their descriptions.
+type OAuthFlow struct {
+	AuthorizationURL string
+	TokenURL         string
+	RefreshURL       string
+	Scopes           map[string]string
+}
+
+// bearerScheme is declared by SpecConfig.WithBearerTokenSecurity.
+var bearerScheme = SecurityScheme{
+	Name:        "Bearer Auth",
+	Type:        SecurityHTTP,
+	Scheme:      "bearer",
+	Description: "Bearer token authentication",
+}
+
+func securitySchemes(config SpecConfig) []SecurityScheme {
+	schemes := slices.Clone(config.SecuritySchemes)
+	if config.WithBearerTokenSecurity && !slices.ContainsFunc(schemes, func(s SecurityScheme) bool { return s.Name == bearerScheme.Name }) {
+		schemes = append(schemes, bearerScheme)
+	}
+	return schemes
+}
+
+func (s SecurityScheme) toOpenAPI() (openapi3.SecurityScheme, error) {
+	var scheme openapi3.SecurityScheme
+
+	switch s.Type {
+	case SecurityAPIKey:
+		switch s.In {
+		case APIKeyInHeader, APIKeyInQuery, APIKeyInCookie:
+		default:
+			return scheme, fmt.Errorf("api key location must be header, query or cookie, got %q", s.In)
+		}
+		if s.ParamName == "" {
+			return scheme, fmt.Errorf("api key parameter name is required")
+		}
+		apiKey := openapi3.APIKeySecurityScheme{Name: s.ParamName, In: openapi3.APIKeySecuritySchemeIn(s.In)}
+		if s.Description != "" {
+			apiKey.WithDescription(s.Description)
+		}
+		scheme.WithAPIKeySecurityScheme(apiKey)
+	case SecurityHTTP:
+		if s.Scheme == "" {
+			return scheme, fmt.Errorf("http scheme is required, e.g. basic or bearer")
+		}
+		http := openapi3.HTTPSecurityScheme{Scheme: s.Scheme}
+		if s.BearerFormat != "" {
+			http.WithBearerFormat(s.BearerFormat)
+		}
+		if s.Description != "" {
+			http.WithDescription(s.Description)
+		}
+		scheme.WithHTTPSecurityScheme(http)
+	case SecurityOAuth2:
+		flows, err := s.Flows.toOpenAPI()
+		if err != nil {
+			return scheme, err
+		}
+		oauth2 := openapi3.OAuth2SecurityScheme{Flows: flows}
+		if s.Description != "" {
+			oauth2.WithDescription(s.Description)
+		}
+		scheme.WithOAuth2SecurityScheme(oauth2)
+	case SecurityOpenIDConnect:
+		if s.OpenIDConnectURL == "" {
+			return scheme, fmt.Errorf("openIdConnect URL is required")
+		}
+		oidc := openapi3.OpenIDConnectSecurityScheme{OpenIDConnectURL: s.OpenIDConnectURL}
+		if s.Description != "" {
+			oidc.WithDescription(s.Description)
+		}
+		scheme.WithOpenIDConnectSecurityScheme(oidc)
+	case SecurityMutualTLS:
+		return scheme, fmt.Errorf("mutualTLS requires OpenAPI 3.1, the generated spec is OpenAPI 3.0")
+	default:
+		return scheme, fmt.Errorf("unknown security scheme type %q", s.Type)
+	}
+
+	return scheme, nil
+}
+
+func (f OAuthFlows) toOpenAPI() (openapi3.OAuthFlows, error) {
+	var flows openapi3.OAuthFlows
+
+	if f.Implicit == nil && f.Password == nil && f.ClientCredentials == nil && f.AuthorizationCode == nil {
+		return flows, fmt.Errorf("oauth2 requires at least one flow")
+	}
+
+	if flow := f.Implicit; flow != nil {
+		if flow.AuthorizationURL == "" {
+			return flows, fmt.Errorf("implicit flow requires an authorization URL")
+		}
+		implicit := openapi3.ImplicitOAuthFlow{AuthorizationURL: flow.AuthorizationURL, Scopes: scopes(flow.Scopes)}
+		if flow.RefreshURL != "" {
+			implicit.WithRefreshURL(flow.RefreshURL)
+		}
+		flows.WithImplicit(implicit)
+	}
+	if flow := f.Password; flow != nil {
+		if flow.TokenURL == "" {
+			return flows, fmt.Errorf("password flow requires a token URL")
+		}
+		password := openapi3.PasswordOAuthFlow{TokenURL: flow.TokenURL, Scopes: scopes(flow.Scopes)}
+		if flow.RefreshURL != "" {
+			password.WithRefreshURL(flow.RefreshURL)
+		}
+		password.MapOfAnything = emptyScopes(flow.Scopes)
+		flows.WithPassword(password)
+	}
+	if flow := f.ClientCredentials; flow != nil {
+		if flow.TokenURL == "" {
+			return flows, fmt.Errorf("client credentials flow requires a token URL")
+		}
+		clientCredentials := openapi3.ClientCredentialsFlow{TokenURL: flow.TokenURL, Scopes: scopes(flow.Scopes)}
+		if flow.RefreshURL != "" {
+			clientCredentials.WithRefreshURL(flow.RefreshURL)
+		}
+		clientCredentials.MapOfAnything = emptyScopes(flow.Scopes)
+		flows.WithClientCredentials(clientCredentials)
+	}
+	if flow := f.AuthorizationCode; flow != nil {
+		if flow.AuthorizationURL == "" || flow.TokenURL == "" {
+			return flows, fmt.Errorf("authorization code flow requires an authorization URL and a token URL")
+		}
+		authorizationCode := openapi3.AuthorizationCodeOAuthFlow{
+			AuthorizationURL: flow.AuthorizationURL,
+			TokenURL:         flow.TokenURL,
+			Scopes:           scopes(flow.Scopes),
+		}
+		if flow.RefreshURL != "" {
+			authorizationCode.WithRefreshURL(flow.RefreshURL)
+		}
+		authorizationCode.MapOfAnything = emptyScopes(flow.Scopes)
+		flows.WithAuthorizationCode(authorizationCode)
+	}
+
+	return flows, nil
+}
+
+// scopes is never nil, since OpenAPI requires the scopes map even when it is empty.
+func scopes(s map[string]string) map[string]string {
+	if s == nil {
+		return map[string]string{}
+	}
+	return s
+}
+
+// emptyScopes emits `scopes: {}` for flows whose Scopes field is omitted when empty.
+func emptyScopes(s map[string]string) map[string]any {
+	if len(s) > 0 {
+		return nil
+	}
+	return map[string]any{"scopes": map[string]string{}}
+}
+
+func (f OAuthFlows) hasScope(scope string) bool {
+	for _, flow := range []*OAuthFlow{f.Implicit, f.Password, f.ClientCredentials, f.AuthorizationCode} {
+		if flow == nil {
+			continue
+		}
+		if _, ok := flow.Scopes[scope]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// setSecuritySchemes adds the declared schemes to the spec components.
+func setSecuritySchemes(spec *openapi3.Spec, schemes []SecurityScheme) error {
+	for _, s := range schemes {
+		scheme, err := s.toOpenAPI()
+		if err != nil {
+			return fmt.Errorf("invalid security scheme %q: %w", s.Name, err)
+		}
+		spec.ComponentsEns().SecuritySchemesEns().WithMapOfSecuritySchemeOrRefValuesItem(
+			s.Name,
+			openapi3.SecuritySchemeOrRef{SecurityScheme: &scheme},
+		)
+	}
+	return nil
+}
+
+// checkSecurity returns the problems of security requirements: unknown schemes and,
+// for oauth2, scopes that no flow defines.
+func checkSecurity(requirements []SecurityRequirement, schemes []SecurityScheme) []string {
+	byName := make(map[string]SecurityScheme, len(schemes))
+	for _, s := range schemes {
+		byName[s.Name] = s
+	}
+
+	var problems []string
+	for _, requirement := range requirements {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			scheme, ok := byName[name]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("unknown security scheme %q", name))
+				continue
+			}
+			if scheme.Type != SecurityOAuth2 {
+				continue
+			}
+			for _, scope := range requirement[name] {
+				if !scheme.Flows.hasScope(scope) {
+					problems = append(problems, fmt.Sprintf("scope %q is not defined by security scheme %q", scope, name))
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// securityErrors reports route security requirements that checkSecurity rejects.
+func securityErrors(routes []Route, schemes []SecurityScheme) []RouteError {
+	var errs []RouteError
+	for i, route := range routes {
+		for _, problem := range checkSecurity(route.Security, schemes) {
+			errs = append(errs, RouteError{Index: i, Method: route.Method, Path: route.Path, Message: problem})
+		}
+	}
+	return errs
+}
+
+// applySecurity sets the route requirements on the operation. Routes without
+// requirements use the spec-level ones; an empty non-nil list emits `security: []`,
+// which removes them, e.g. for public endpoints.
+func applySecurity(operation *openapi3.Operation, security []SecurityRequirement) {
+	if security == nil {
+		return
+	}
+
+	if len(security) == 0 {
+		operation.Security = nil
+		if operation.MapOfAnything == nil {
+			operation.MapOfAnything = make(map[string]any)
+		}
+		// Operation.Security is omitted when empty
+		operation.MapOfAnything["security"] = []any{}
+		return
+	}
+
+	for _, requirement := range security {
+		operation.Security = append(operation.Security, requirement)
+	}
+}
@@ -0,0 +1,138 @@
+package specgen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+func securityConfig() specgen.SpecConfig {
+	return specgen.SpecConfig{
+		SecuritySchemes: []specgen.SecurityScheme{
+			{Name: "apiKey", Type: specgen.SecurityAPIKey, In: specgen.APIKeyInHeader, ParamName: "X-API-Key"},
+			{Name: "session", Type: specgen.SecurityAPIKey, In: specgen.APIKeyInCookie, ParamName: "sid"},
+			{Name: "basic", Type: specgen.SecurityHTTP, Scheme: "basic"},
+			{Name: "jwt", Type: specgen.SecurityHTTP, Scheme: "bearer", BearerFormat: "JWT"},
+			{
+				Name: "oauth",
+				Type: specgen.SecurityOAuth2,
+				Flows: specgen.OAuthFlows{
+					AuthorizationCode: &specgen.OAuthFlow{
+						AuthorizationURL: "https://auth.example.com/authorize",
+						TokenURL:         "https://auth.example.com/token",
+						Scopes:           map[string]string{"orders:read": "Read orders", "orders:write": "Write orders"},
+					},
+					ClientCredentials: &specgen.OAuthFlow{TokenURL: "https://auth.example.com/token"},
+				},
+			},
+			{Name: "oidc", Type: specgen.SecurityOpenIDConnect, OpenIDConnectURL: "https://auth.example.com/.well-known/openid-configuration"},
+		},
+		Security: []specgen.SecurityRequirement{{"jwt": {}}, {"apiKey": {}}},
+	}
+}
+
+func securityRoutes() []specgen.Route {
+	return []specgen.Route{
+		{
+			Path:      "/health",
+			Method:    "GET",
+			Security:  []specgen.SecurityRequirement{},
+			Responses: []specgen.RouteResponse{{StatusCode: 204, Response: nil}},
+		},
+		{
+			Path:      "/orders",
+			Method:    "GET",
+			Responses: []specgen.RouteResponse{{StatusCode: 204, Response: nil}},
+		},
+		{
+			Path:      "/orders",
+			Method:    "POST",
+			Security:  []specgen.SecurityRequirement{{"oauth": {"orders:write"}}},
+			Responses: []specgen.RouteResponse{{StatusCode: 204, Response: nil}},
+		},
+	}
+}
+
+func TestGenerateOpenAPISpec_SecuritySchemes(t *testing.T) {
+	content, err := specgen.GenerateOpenAPISpecBytes(securityConfig(), specgen.FormatYAML, securityRoutes())
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Security []map[string][]string `yaml:"security"`
+		Paths    map[string]map[string]struct {
+			Security *[]map[string][]string `yaml:"security"`
+		} `yaml:"paths"`
+		Components struct {
+			SecuritySchemes map[string]map[string]any `yaml:"securitySchemes"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	schemes := spec.Components.SecuritySchemes
+	if len(schemes) != 6 {
+		t.Fatalf("Expected 6 security schemes, got: %v", schemes)
+	}
+	if s := schemes["apiKey"]; s["type"] != "apiKey" || s["in"] != "header" || s["name"] != "X-API-Key" {
+		t.Errorf("Unexpected api key scheme: %v", s)
+	}
+	if s := schemes["jwt"]; s["scheme"] != "bearer" || s["bearerFormat"] != "JWT" {
+		t.Errorf("Unexpected bearer scheme: %v", s)
+	}
+	if s := schemes["oidc"]; s["type"] != "openIdConnect" || s["openIdConnectUrl"] == nil {
+		t.Errorf("Unexpected openIdConnect scheme: %v", s)
+	}
+	flows, _ := schemes["oauth"]["flows"].(map[string]any)
+	if _, ok := flows["authorizationCode"]; !ok {
+		t.Errorf("Expected authorizationCode flow, got: %v", flows)
+	}
+	if clientCredentials, _ := flows["clientCredentials"].(map[string]any); clientCredentials["scopes"] == nil {
+		t.Errorf("Expected an empty scopes map on the client credentials flow, got: %v", clientCredentials)
+	}
+
+	if len(spec.Security) != 2 {
+		t.Errorf("Expected two spec-level alternatives, got: %v", spec.Security)
+	}
+
+	if health := spec.Paths["/health"]["get"].Security; health == nil || len(*health) != 0 {
+		t.Errorf("Expected `security: []` on the public route, got: %v", health)
+	}
+	if list := spec.Paths["/orders"]["get"].Security; list != nil {
+		t.Errorf("Expected the list route to inherit spec security, got: %v", *list)
+	}
+	create := spec.Paths["/orders"]["post"].Security
+	if create == nil || len(*create) != 1 || len((*create)[0]["oauth"]) != 1 || (*create)[0]["oauth"][0] != "orders:write" {
+		t.Errorf("Expected oauth orders:write on the create route, got: %v", create)
+	}
+}
+
+func TestGenerateOpenAPISpec_SecurityErrors(t *testing.T) {
+	routes := securityRoutes()
+	routes[1].Security = []specgen.SecurityRequirement{{"missing": {}}}
+	routes[2].Security = []specgen.SecurityRequirement{{"oauth": {"orders:delete"}}}
+
+	_, err := specgen.GenerateOpenAPISpecBytes(securityConfig(), specgen.FormatYAML, routes)
+	if err == nil {
+		t.Fatal("Expected an error for unknown schemes and scopes")
+	}
+	for _, want := range []string{
+		`route #1 GET /orders: unknown security scheme "missing"`,
+		`route #2 POST /orders: scope "orders:delete" is not defined by security scheme "oauth"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+
+	config := securityConfig()
+	config.SecuritySchemes = append(config.SecuritySchemes, specgen.SecurityScheme{Name: "mtls", Type: specgen.SecurityMutualTLS})
+	_, err = specgen.GenerateOpenAPISpecBytes(config, specgen.FormatYAML, securityRoutes())
+	if err == nil || !strings.Contains(err.Error(), `invalid security scheme "mtls": mutualTLS requires OpenAPI 3.1`) {
+		t.Errorf("Expected mutualTLS to be rejected in OpenAPI 3.0, got: %v", err)
+	}
+}
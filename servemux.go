@@ -0,0 +1,65 @@
+package specgen
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ServeMux is an http.ServeMux that records a Route for every handler registered with
+// HandleRoute, so the spec and the router are built from the same registrations.
+// Handlers registered with Handle and HandleFunc are served but not documented.
+type ServeMux struct {
+	*http.ServeMux
+	routes []Route
+}
+
+func NewServeMux() *ServeMux {
+	return &ServeMux{ServeMux: http.NewServeMux()}
+}
+
+// HandleRoute registers the handler for a Go 1.22 pattern such as "GET /users/{id}".
+// The method and path of the pattern replace route.Method and route.Path; a pattern
+// without a method keeps route.Method.
+func (m *ServeMux) HandleRoute(pattern string, route Route, handler http.Handler) {
+	m.Handle(pattern, handler)
+
+	method, path := parsePattern(pattern)
+	if method != "" {
+		route.Method = method
+	}
+	route.Path = path
+	m.routes = append(m.routes, route)
+}
+
+func (m *ServeMux) HandleRouteFunc(pattern string, route Route, handler func(http.ResponseWriter, *http.Request)) {
+	m.HandleRoute(pattern, route, http.HandlerFunc(handler))
+}
+
+func (m *ServeMux) Routes() []Route {
+	return slices.Clone(m.routes)
+}
+
+// parsePattern splits a ServeMux pattern "[METHOD ][HOST]/[PATH]" into its method and
+// an OpenAPI path: the host is dropped, {name...} becomes {name} and {$} is removed.
+func parsePattern(pattern string) (method, path string) {
+	pattern = strings.TrimSpace(pattern)
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		method, pattern = pattern[:i], strings.TrimLeft(pattern[i:], " \t")
+	}
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:]
+	}
+
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "{$}":
+			segments[i] = ""
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}"):
+			segments[i] = strings.TrimSuffix(segment, "...}") + "}"
+		}
+	}
+
+	return method, strings.Join(segments, "/")
+}
@@ -0,0 +1,66 @@
+package specgen_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+)
+
+type GetFileRequest struct {
+	Bucket string `path:"bucket"`
+	Key    string `path:"key"`
+}
+
+type FileResponse struct {
+	Name string `json:"name"`
+}
+
+func TestServeMux_HandleRoute(t *testing.T) {
+	mux := specgen.NewServeMux()
+
+	mux.HandleRouteFunc("GET /buckets/{bucket}/files/{key...}", specgen.Route{
+		Summary: "Get file",
+		Request: GetFileRequest{},
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 200, Response: FileResponse{}},
+		},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.PathValue("bucket") + ":" + r.PathValue("key")))
+	})
+	mux.HandleRouteFunc("example.com/{$}", specgen.Route{
+		Method: "GET",
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 204, Response: nil},
+		},
+	}, func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("GET /internal", func(w http.ResponseWriter, r *http.Request) {})
+
+	routes := mux.Routes()
+	if len(routes) != 2 {
+		t.Fatalf("Expected 2 routes, got %d", len(routes))
+	}
+	if routes[0].Method != "GET" || routes[0].Path != "/buckets/{bucket}/files/{key}" || routes[0].Summary != "Get file" {
+		t.Errorf("Unexpected first route: %+v", routes[0])
+	}
+	if routes[1].Method != "GET" || routes[1].Path != "/" {
+		t.Errorf("Expected host and {$} to be dropped, got: %s %s", routes[1].Method, routes[1].Path)
+	}
+
+	// handlers are still served by the mux
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest("GET", "/buckets/docs/files/a/b.txt", nil))
+	if recorder.Body.String() != "docs:a/b.txt" {
+		t.Errorf("Unexpected handler response: %q", recorder.Body.String())
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+	if !strings.Contains(string(content), "/buckets/{bucket}/files/{key}:") {
+		t.Errorf("Expected the mux path in the spec, got:\n%s", content)
+	}
+}
@@ -1,64 +1,171 @@
 package specgen
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
-	"os"
+	"io"
+	"path/filepath"
+	"strings"
 
-	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/jsonschema-go"
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
 type SpecConfig struct {
-	Title                   *string
-	Description             *string
-	Version                 *string
+	Title          *string
+	Description    *string
+	Version        *string
+	TermsOfService *string
+	Contact        *Contact
+	License        *License
+	Servers        []Server
+	ExternalDocs   *ExternalDocs
+	// Extensions are added to the document root, keys must start with x-.
+	Extensions map[string]any
+
+	// WithBearerTokenSecurity declares an http bearer scheme named "Bearer Auth".
 	WithBearerTokenSecurity bool
+	SecuritySchemes         []SecurityScheme
+	// Security applies to every route that does not declare its own.
+	Security []SecurityRequirement
+
+	Tags         []SpecTag
+	Validator    ValidatorConfig
+	SchemaNaming SchemaNaming
+}
+
+type OutputFormat string
+
+const (
+	FormatYAML OutputFormat = "yaml"
+	FormatJSON OutputFormat = "json"
+)
+
+// FormatFromPath detects the output format from the file extension, falling back to YAML.
+func FormatFromPath(path string) OutputFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	default:
+		return FormatYAML
+	}
 }
 
 func GenerateOpenAPISpec(config SpecConfig, outputFile string, routes []Route) error {
-	reflector := openapi3.NewReflector()
+	return NewSpecBuilder(config).AddRoutes(routes...).WriteFile(outputFile)
+}
 
-	if config.Title != nil {
-		reflector.Spec.Info.WithTitle(*config.Title)
+func GenerateOpenAPISpecTo(w io.Writer, format OutputFormat, config SpecConfig, routes []Route) error {
+	return NewSpecBuilder(config).AddRoutes(routes...).Write(w, format)
+}
+
+func GenerateOpenAPISpecBytes(config SpecConfig, format OutputFormat, routes []Route) ([]byte, error) {
+	return NewSpecBuilder(config).AddRoutes(routes...).Bytes(format)
+}
+
+func buildReflector(config SpecConfig, routes []Route) (*openapi3.Reflector, error) {
+	schemes := securitySchemes(config)
+
+	errs := append(routeErrors(routes), securityErrors(routes, schemes)...)
+	if len(errs) > 0 {
+		return nil, &RouteValidationError{Errors: errs}
 	}
-	if config.Description != nil {
-		reflector.Spec.Info.WithDescription(*config.Description)
+	if problems := checkSecurity(config.Security, schemes); len(problems) > 0 {
+		return nil, fmt.Errorf("invalid spec security: %s", strings.Join(problems, "; "))
 	}
-	if config.Version != nil {
-		reflector.Spec.Info.WithVersion(*config.Version)
+
+	reflector := openapi3.NewReflector()
+
+	if err := setDocumentInfo(reflector.Spec, config); err != nil {
+		return nil, err
 	}
 
-	if config.WithBearerTokenSecurity {
-		reflector.Spec.SetHTTPBearerTokenSecurity("Bearer Auth", "Bearer token authentication", "")
+	if err := setSecuritySchemes(reflector.Spec, schemes); err != nil {
+		return nil, err
+	}
+	for _, requirement := range config.Security {
+		reflector.Spec.Security = append(reflector.Spec.Security, requirement)
 	}
 
-	for _, route := range routes {
-		ParseValidatorV10(reflector, route.Request)
+	RegisterValidatorV10WithConfig(reflector, config.Validator)
+
+	namer := newSchemaNamer(config.SchemaNaming)
+	reflector.DefaultOptions = append(reflector.DefaultOptions, jsonschema.InterceptDefName(namer.defName))
 
+	for _, route := range routes {
 		op, err := reflector.NewOperationContext(route.Method, route.Path)
 		if err != nil {
-			return fmt.Errorf("failed to create operation context: %w", err)
+			return nil, fmt.Errorf("failed to create operation context: %w", err)
 		}
 
-		// TODO: parse params, query, etc. tags from Request struct
-		op.AddReqStructure(route.Request)
-
-		for _, response := range route.Responses {
-			op.AddRespStructure(response.Response, func(cu *openapi.ContentUnit) {
-				cu.HTTPStatus = response.StatusCode
-			})
+		if len(route.Tags) > 0 {
+			op.SetTags(route.Tags...)
+		}
+		if route.Summary != "" {
+			op.SetSummary(route.Summary)
 		}
+		if route.Description != "" {
+			op.SetDescription(route.Description)
+		}
+
+		// Fields tagged with path, query, header or cookie become parameters, the rest is the body
+		addRequest(op, route)
+
+		addResponses(op, route.Responses)
 
 		if err := reflector.AddOperation(op); err != nil {
-			return fmt.Errorf("failed to add operation: %w", err)
+			return nil, fmt.Errorf("failed to add operation: %w", err)
+		}
+		if namer.err != nil {
+			return nil, fmt.Errorf("failed to name schemas of %s %s: %w", route.Method, route.Path, namer.err)
+		}
+
+		encoding, err := requestEncoding(reflector, route.RequestEncoding)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add request encoding of %s %s: %w", route.Method, route.Path, err)
+		}
+
+		var exampleErr error
+		updateOperation(reflector.Spec, route.Method, route.Path, func(operation *openapi3.Operation) {
+			applyParamSemantics(operation, route.Request, config.Validator)
+			applyRequestBody(operation, route.RequestContentType, encoding)
+			dropFormQueryParams(operation, route.Request)
+			applySecurity(operation, route.Security)
+			exampleErr = applyExamples(operation, route)
+		})
+		if exampleErr != nil {
+			return nil, fmt.Errorf("failed to add examples of %s %s: %w", route.Method, route.Path, exampleErr)
 		}
 	}
 
-	yaml, err := reflector.Spec.MarshalYAML()
-	if err != nil {
-		return fmt.Errorf("failed to marshal yaml spec: %w", err)
+	if tags := buildTags(config.Tags, routes); len(tags) > 0 {
+		reflector.Spec.Tags = tags
 	}
 
-	os.WriteFile(outputFile, yaml, 0644)
-	return nil
+	return reflector, nil
+}
+
+func marshalSpec(spec *openapi3.Spec, format OutputFormat) ([]byte, error) {
+	switch format {
+	case FormatYAML, "":
+		yaml, err := spec.MarshalYAML()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal yaml spec: %w", err)
+		}
+		return yaml, nil
+	case FormatJSON:
+		raw, err := spec.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal json spec: %w", err)
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, raw, "", "  "); err != nil {
+			return nil, fmt.Errorf("failed to indent json spec: %w", err)
+		}
+		indented.WriteByte('\n')
+		return indented.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported output format: %q", format)
+	}
 }
@@ -1,6 +1,8 @@
 package specgen_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -318,10 +320,10 @@ func TestGenerateOpenAPISpec_InvalidMethod(t *testing.T) {
 
 	err := specgen.GenerateOpenAPISpec(config, outputFile, routes)
 	if err == nil {
-		t.Error("Expected error for invalid HTTP method, but got nil")
+		t.Fatal("Expected error for invalid HTTP method, but got nil")
 	}
-	if !strings.Contains(err.Error(), "failed to create operation context") {
-		t.Errorf("Expected error about operation context, got: %v", err)
+	if !strings.Contains(err.Error(), `unknown HTTP method "INVALID_METHOD"`) {
+		t.Errorf("Expected error about unknown HTTP method, got: %v", err)
 	}
 }
 
@@ -541,3 +543,208 @@ func TestGenerateOpenAPISpec_Validator(t *testing.T) {
 		t.Errorf("Birthday field should have format: date-time, got: %s", *birthdayProp.Format)
 	}
 }
+
+func TestGenerateOpenAPISpec_JSONFromExtension(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputFile := filepath.Join(tmpDir, "spec.json")
+
+	title := "JSON API"
+	config := specgen.SpecConfig{
+		Title: &title,
+	}
+
+	routes := []specgen.Route{
+		{
+			Path:    "/users",
+			Method:  "GET",
+			Request: struct{}{},
+			Responses: []specgen.RouteResponse{
+				{
+					StatusCode: 200,
+					Response:   []UserResponse{},
+				},
+			},
+		},
+	}
+
+	err := specgen.GenerateOpenAPISpec(config, outputFile, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpec failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	var spec map[string]any
+	if err := json.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Output should be valid JSON: %v", err)
+	}
+	if spec["openapi"] == nil {
+		t.Error("JSON should contain openapi version")
+	}
+}
+
+func TestGenerateOpenAPISpecTo_Writer(t *testing.T) {
+	title := "Writer API"
+	config := specgen.SpecConfig{
+		Title: &title,
+	}
+
+	routes := []specgen.Route{
+		{
+			Path:    "/health",
+			Method:  "GET",
+			Request: struct{}{},
+			Responses: []specgen.RouteResponse{
+				{
+					StatusCode: 200,
+					Response:   struct{}{},
+				},
+			},
+		},
+	}
+
+	var yamlBuf bytes.Buffer
+	if err := specgen.GenerateOpenAPISpecTo(&yamlBuf, specgen.FormatYAML, config, routes); err != nil {
+		t.Fatalf("GenerateOpenAPISpecTo failed: %v", err)
+	}
+	var yamlSpec OpenAPISpec
+	if err := yaml.Unmarshal(yamlBuf.Bytes(), &yamlSpec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+	if yamlSpec.Info.Title != "Writer API" {
+		t.Errorf("Expected title 'Writer API', got: %s", yamlSpec.Info.Title)
+	}
+
+	var jsonBuf bytes.Buffer
+	if err := specgen.GenerateOpenAPISpecTo(&jsonBuf, specgen.FormatJSON, config, routes); err != nil {
+		t.Fatalf("GenerateOpenAPISpecTo failed: %v", err)
+	}
+	var jsonSpec map[string]any
+	if err := json.Unmarshal(jsonBuf.Bytes(), &jsonSpec); err != nil {
+		t.Fatalf("Output should be valid JSON: %v", err)
+	}
+
+	if _, err := specgen.GenerateOpenAPISpecBytes(config, "xml", routes); err == nil {
+		t.Error("Expected error for unsupported output format, but got nil")
+	}
+}
+
+func TestGenerateOpenAPISpec_WriteError(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "missing", "spec.yaml")
+
+	err := specgen.GenerateOpenAPISpec(specgen.SpecConfig{}, outputFile, nil)
+	if err == nil {
+		t.Fatal("Expected error when writing to a missing directory, but got nil")
+	}
+	if !strings.Contains(err.Error(), "failed to write spec file") {
+		t.Errorf("Expected write error, got: %v", err)
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	cases := map[string]specgen.OutputFormat{
+		"openapi.yaml": specgen.FormatYAML,
+		"openapi.yml":  specgen.FormatYAML,
+		"openapi.json": specgen.FormatJSON,
+		"OPENAPI.JSON": specgen.FormatJSON,
+		"openapi":      specgen.FormatYAML,
+	}
+
+	for path, expected := range cases {
+		if got := specgen.FormatFromPath(path); got != expected {
+			t.Errorf("FormatFromPath(%q): expected %s, got %s", path, expected, got)
+		}
+	}
+}
+
+func TestGenerateOpenAPISpec_TagsAndSummary(t *testing.T) {
+	config := specgen.SpecConfig{
+		Tags: []specgen.SpecTag{
+			{
+				Name:        "users",
+				Description: "User management",
+				ExternalDocs: &specgen.ExternalDocs{
+					URL:         "https://example.com/docs/users",
+					Description: "User guide",
+				},
+			},
+			{Name: "admin", Description: "Administration"},
+		},
+	}
+
+	routes := []specgen.Route{
+		{
+			Tags:        []string{"users"},
+			Summary:     "List users",
+			Description: "Retrieve all users",
+			Path:        "/users",
+			Method:      "GET",
+			Request:     struct{}{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 200, Response: []UserResponse{}},
+			},
+		},
+		{
+			Tags:    []string{"health"},
+			Path:    "/health",
+			Method:  "GET",
+			Request: struct{}{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 200, Response: struct{}{}},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(config, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Tags []struct {
+			Name         string `yaml:"name"`
+			Description  string `yaml:"description"`
+			ExternalDocs *struct {
+				URL string `yaml:"url"`
+			} `yaml:"externalDocs"`
+		} `yaml:"tags"`
+		Paths map[string]map[string]struct {
+			Tags        []string `yaml:"tags"`
+			Summary     string   `yaml:"summary"`
+			Description string   `yaml:"description"`
+		} `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	expectedOrder := []string{"users", "admin", "health"}
+	if len(spec.Tags) != len(expectedOrder) {
+		t.Fatalf("Expected %d tags, got %d", len(expectedOrder), len(spec.Tags))
+	}
+	for i, name := range expectedOrder {
+		if spec.Tags[i].Name != name {
+			t.Errorf("Expected tag %d to be '%s', got '%s'", i, name, spec.Tags[i].Name)
+		}
+	}
+	if spec.Tags[0].Description != "User management" {
+		t.Errorf("Expected users tag description, got: %s", spec.Tags[0].Description)
+	}
+	if spec.Tags[0].ExternalDocs == nil || spec.Tags[0].ExternalDocs.URL != "https://example.com/docs/users" {
+		t.Error("Expected users tag externalDocs url")
+	}
+
+	listUsers := spec.Paths["/users"]["get"]
+	if len(listUsers.Tags) != 1 || listUsers.Tags[0] != "users" {
+		t.Errorf("Expected operation tags [users], got: %v", listUsers.Tags)
+	}
+	if listUsers.Summary != "List users" {
+		t.Errorf("Expected summary 'List users', got: %s", listUsers.Summary)
+	}
+	if listUsers.Description != "Retrieve all users" {
+		t.Errorf("Expected description 'Retrieve all users', got: %s", listUsers.Description)
+	}
+}
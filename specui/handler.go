@@ -0,0 +1,75 @@
+// Package specui serves a generated OpenAPI spec together with Swagger UI and Redoc.
+// Pages only load embedded assets, so the documentation works without network access.
+package specui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/swgui"
+	"github.com/swaggest/swgui/v5emb"
+)
+
+type Config struct {
+	// BasePath is the path the handler is mounted at, e.g. "/docs". Defaults to "/".
+	BasePath string
+	// Title of the documentation pages, defaults to the spec title.
+	Title string
+}
+
+// NewHandler serves, relative to BasePath:
+//
+//	openapi.json  the spec as JSON
+//	openapi.yaml  the spec as YAML
+//	swagger/      Swagger UI
+//	redoc/        Redoc, from the embedded assets/redoc.standalone.js
+//
+// The base path itself redirects to Swagger UI. The spec is marshaled once, changes
+// made to it afterwards are not served.
+func NewHandler(spec *openapi3.Spec, config Config) (http.Handler, error) {
+	jsonSpec, err := spec.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json spec: %w", err)
+	}
+	yamlSpec, err := spec.MarshalYAML()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal yaml spec: %w", err)
+	}
+
+	basePath := "/" + strings.Trim(config.BasePath, "/")
+	if basePath != "/" {
+		basePath += "/"
+	}
+
+	title := config.Title
+	if title == "" {
+		title = spec.Info.Title
+	}
+
+	redoc, err := newRedocHandler(title, basePath+"openapi.json", basePath+"redoc/")
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET "+basePath+"openapi.json", specHandler("application/json", jsonSpec))
+	mux.Handle("GET "+basePath+"openapi.yaml", specHandler("application/yaml", yamlSpec))
+	mux.Handle("GET "+basePath+"swagger/", v5emb.NewHandlerWithConfig(swgui.Config{
+		Title:       title,
+		SwaggerJSON: basePath + "openapi.json",
+		BasePath:    basePath + "swagger/",
+	}))
+	mux.Handle("GET "+basePath+"redoc/", redoc)
+	mux.Handle("GET "+basePath+"{$}", http.RedirectHandler(basePath+"swagger/", http.StatusFound))
+
+	return mux, nil
+}
+
+func specHandler(contentType string, content []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(content)
+	})
+}
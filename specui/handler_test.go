@@ -0,0 +1,110 @@
+package specui_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"github.com/lutfiandri/go-specgen/specui"
+)
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+func newTestServer(t *testing.T, config specui.Config) *httptest.Server {
+	t.Helper()
+
+	title := "Docs API"
+	spec, err := specgen.NewSpecBuilder(specgen.SpecConfig{Title: &title}).AddRoute(specgen.Route{
+		Path:   "/health",
+		Method: "GET",
+		Responses: []specgen.RouteResponse{
+			{StatusCode: 200, Response: HealthResponse{}},
+		},
+	}).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	handler, err := specui.NewHandler(spec, config)
+	if err != nil {
+		t.Fatalf("NewHandler failed: %v", err)
+	}
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, url string) (*http.Response, string) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read body of %s: %v", url, err)
+	}
+	return resp, string(body)
+}
+
+func TestNewHandler_Spec(t *testing.T) {
+	server := newTestServer(t, specui.Config{BasePath: "/docs"})
+
+	resp, body := get(t, server.URL+"/docs/openapi.json")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Unexpected openapi.json response: %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(body, `"/health"`) {
+		t.Errorf("Expected JSON spec to contain /health, got: %s", body)
+	}
+
+	resp, body = get(t, server.URL+"/docs/openapi.yaml")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/yaml" {
+		t.Errorf("Unexpected openapi.yaml response: %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(body, "title: Docs API") {
+		t.Errorf("Expected YAML spec to contain the title, got: %s", body)
+	}
+
+	if resp, _ := get(t, server.URL+"/openapi.json"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 outside the base path, got %d", resp.StatusCode)
+	}
+}
+
+func TestNewHandler_UI(t *testing.T) {
+	server := newTestServer(t, specui.Config{})
+
+	resp, body := get(t, server.URL+"/")
+	if resp.Request.URL.Path != "/swagger/" {
+		t.Errorf("Expected the base path to redirect to Swagger UI, got %s", resp.Request.URL.Path)
+	}
+	if !strings.Contains(body, "Docs API") || !strings.Contains(body, "/openapi.json") {
+		t.Errorf("Expected Swagger UI page with title and spec URL, got: %s", body)
+	}
+
+	// Swagger UI assets are embedded
+	if resp, _ := get(t, server.URL+"/swagger/swagger-ui-bundle.js"); resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected embedded Swagger UI bundle, got %d", resp.StatusCode)
+	}
+
+	// Redoc is only served from the embedded bundle, never from a CDN
+	resp, body = get(t, server.URL+"/redoc/")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected Redoc page, got %d: %s", resp.StatusCode, body)
+	}
+	if !strings.Contains(body, `spec-url="/openapi.json"`) || !strings.Contains(body, `src="/redoc/redoc.standalone.js"`) {
+		t.Errorf("Expected Redoc page with embedded bundle pointing at the spec, got: %s", body)
+	}
+	if resp, _ := get(t, server.URL+"/redoc/redoc.standalone.js"); resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected embedded Redoc bundle, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,62 @@
+package specui
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// The bundle must be committed to be embedded for users of the module, go generate only refreshes it.
+//go:generate curl -fsSL -o assets/redoc.standalone.js https://cdn.redoc.ly/redoc/v2.5.0/bundles/redoc.standalone.js
+
+//go:embed assets
+var assets embed.FS
+
+const redocScript = "redoc.standalone.js"
+
+var redocTemplate = template.Must(template.New("redoc").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{ .Title }}</title>
+  <style>body { margin: 0; padding: 0; }</style>
+</head>
+<body>
+  <redoc spec-url="{{ .SpecURL }}"></redoc>
+  <script src="{{ .ScriptURL }}"></script>
+</body>
+</html>
+`))
+
+// newRedocHandler fails when the Redoc bundle is not embedded, the page is never pointed
+// at a CDN so that it cannot silently break without network access.
+func newRedocHandler(title, specURL, basePath string) (http.Handler, error) {
+	script, err := fs.ReadFile(assets, "assets/"+redocScript)
+	if err != nil {
+		return nil, fmt.Errorf("redoc bundle assets/%s is not embedded, run go generate ./specui and commit it: %w", redocScript, err)
+	}
+
+	var page bytes.Buffer
+	err = redocTemplate.Execute(&page, struct{ Title, SpecURL, ScriptURL string }{title, specURL, basePath + redocScript})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render redoc page: %w", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, basePath) {
+		case "":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write(page.Bytes())
+		case redocScript:
+			w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+			_, _ = w.Write(script)
+		default:
+			http.NotFound(w, r)
+		}
+	}), nil
+}
@@ -0,0 +1,57 @@
+package specgen
+
+import "github.com/swaggest/openapi-go/openapi3"
+
+type ExternalDocs struct {
+	URL         string
+	Description string
+}
+
+// SpecTag describes a tag group. Tags are rendered in the order they are declared
+// in SpecConfig.Tags, followed by any undeclared tags used by routes.
+type SpecTag struct {
+	Name         string
+	Description  string
+	ExternalDocs *ExternalDocs
+}
+
+func (d ExternalDocs) toOpenAPI() openapi3.ExternalDocumentation {
+	docs := openapi3.ExternalDocumentation{URL: d.URL}
+	if d.Description != "" {
+		docs.WithDescription(d.Description)
+	}
+	return docs
+}
+
+func buildTags(declared []SpecTag, routes []Route) []openapi3.Tag {
+	tags := make([]openapi3.Tag, 0, len(declared))
+	seen := make(map[string]bool)
+
+	for _, specTag := range declared {
+		if seen[specTag.Name] {
+			continue
+		}
+		seen[specTag.Name] = true
+
+		tag := openapi3.Tag{Name: specTag.Name}
+		if specTag.Description != "" {
+			tag.WithDescription(specTag.Description)
+		}
+		if specTag.ExternalDocs != nil {
+			tag.WithExternalDocs(specTag.ExternalDocs.toOpenAPI())
+		}
+		tags = append(tags, tag)
+	}
+
+	for _, route := range routes {
+		for _, name := range route.Tags {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			tags = append(tags, openapi3.Tag{Name: name})
+		}
+	}
+
+	return tags
+}
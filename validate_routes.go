@@ -0,0 +1,173 @@
+package specgen
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var httpMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+type RouteError struct {
+	Index   int
+	Method  string
+	Path    string
+	Message string
+}
+
+func (e RouteError) Error() string {
+	return fmt.Sprintf("route #%d %s %s: %s", e.Index, e.Method, e.Path, e.Message)
+}
+
+// RouteValidationError aggregates every problem found in a route list.
+type RouteValidationError struct {
+	Errors []RouteError
+}
+
+func (e *RouteValidationError) Error() string {
+	lines := make([]string, 0, len(e.Errors)+1)
+	lines = append(lines, "invalid route definitions:")
+	for _, routeErr := range e.Errors {
+		lines = append(lines, "  - "+routeErr.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateRoutes checks route definitions before any reflection happens and
+// returns a *RouteValidationError listing all problems, or nil.
+func ValidateRoutes(routes []Route) error {
+	if errs := routeErrors(routes); len(errs) > 0 {
+		return &RouteValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func routeErrors(routes []Route) []RouteError {
+	var errs []RouteError
+	report := func(index int, route Route, format string, args ...any) {
+		errs = append(errs, RouteError{
+			Index:   index,
+			Method:  route.Method,
+			Path:    route.Path,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	operations := make(map[string]int)
+	templates := make(map[string]int)
+
+	for i, route := range routes {
+		method := strings.ToUpper(route.Method)
+		if !httpMethods[method] {
+			report(i, route, "unknown HTTP method %q", route.Method)
+		}
+
+		pathValid := true
+		if msg := checkPath(route.Path); msg != "" {
+			report(i, route, "malformed path: %s", msg)
+			pathValid = false
+		}
+
+		if pathValid {
+			if msg := checkPathParams(route); msg != "" {
+				report(i, route, "%s", msg)
+			}
+
+			key := method + " " + route.Path
+			if first, ok := operations[key]; ok {
+				report(i, route, "duplicate route, already defined by route #%d", first)
+			} else {
+				operations[key] = i
+			}
+
+			template := pathParamPattern.ReplaceAllString(route.Path, "{}")
+			if first, ok := templates[template]; !ok {
+				templates[template] = i
+			} else if routes[first].Path != route.Path {
+				report(i, route, "path template conflicts with %s defined by route #%d", routes[first].Path, first)
+			}
+		}
+
+		for _, msg := range append(requestErrors(route), exampleErrors(route)...) {
+			report(i, route, "%s", msg)
+		}
+
+		if len(route.Responses) == 0 {
+			report(i, route, "no responses defined")
+		}
+
+		contentTypes := make(map[string]bool)
+		headers := make(map[int]bool)
+		for _, response := range route.Responses {
+			contentType := response.ContentType
+			if contentType == "" {
+				contentType = mimeJSON
+			}
+			key := fmt.Sprintf("%d %s", response.StatusCode, contentType)
+			if contentTypes[key] {
+				if response.ContentType == "" {
+					report(i, route, "duplicate response status code %d", response.StatusCode)
+				} else {
+					report(i, route, "duplicate response status code %d with content type %s", response.StatusCode, response.ContentType)
+				}
+			}
+			contentTypes[key] = true
+
+			if response.Headers != nil {
+				if headers[response.StatusCode] {
+					report(i, route, "headers of response status code %d are declared more than once", response.StatusCode)
+				}
+				headers[response.StatusCode] = true
+			}
+		}
+	}
+
+	return errs
+}
+
+func checkPath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return "must start with /"
+	}
+
+	seen := make(map[string]bool)
+	depth := 0
+	start := 0
+	for i, c := range path {
+		switch c {
+		case '{':
+			if depth > 0 {
+				return "nested {"
+			}
+			depth++
+			start = i + 1
+		case '}':
+			if depth == 0 {
+				return "unmatched }"
+			}
+			depth--
+			name := path[start:i]
+			if name == "" {
+				return "empty parameter name"
+			}
+			if seen[name] {
+				return fmt.Sprintf("parameter %q is used more than once", name)
+			}
+			seen[name] = true
+		}
+	}
+	if depth > 0 {
+		return "unclosed {"
+	}
+
+	return ""
+}
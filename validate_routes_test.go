@@ -0,0 +1,90 @@
+package specgen_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+)
+
+func TestValidateRoutes_AggregatesErrors(t *testing.T) {
+	ok := []specgen.RouteResponse{{StatusCode: 200, Response: struct{}{}}}
+	userParams := struct {
+		ID int `path:"id"`
+	}{}
+	userIDParams := struct {
+		UserID int `path:"userId"`
+	}{}
+
+	routes := []specgen.Route{
+		{Path: "/users", Method: "GET", Request: struct{}{}, Responses: ok},
+		{Path: "/users", Method: "get", Request: struct{}{}, Responses: ok},
+		{Path: "/users", Method: "FETCH", Request: struct{}{}, Responses: ok},
+		{Path: "users/{id", Method: "POST", Request: struct{}{}, Responses: ok},
+		{Path: "/users/{id}", Method: "GET", Request: userParams, Responses: []specgen.RouteResponse{
+			{StatusCode: 200, Response: UserResponse{}},
+			{StatusCode: 200, Response: ErrorResponse{}},
+		}},
+		{Path: "/users/{userId}", Method: "DELETE", Request: userIDParams},
+		{Path: "/users/{id}/posts/{postId}", Method: "GET", Request: userParams, Responses: ok},
+	}
+
+	err := specgen.ValidateRoutes(routes)
+	if err == nil {
+		t.Fatal("Expected validation error, but got nil")
+	}
+
+	var validationErr *specgen.RouteValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *RouteValidationError, got %T", err)
+	}
+
+	expected := []struct {
+		index   int
+		message string
+	}{
+		{1, "duplicate route, already defined by route #0"},
+		{2, `unknown HTTP method "FETCH"`},
+		{3, "malformed path"},
+		{4, "duplicate response status code 200"},
+		{5, "path template conflicts with /users/{id} defined by route #4"},
+		{5, "no responses defined"},
+		{6, `path parameter "postId"`},
+	}
+
+	if len(validationErr.Errors) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d:\n%v", len(expected), len(validationErr.Errors), err)
+	}
+	for i, e := range expected {
+		got := validationErr.Errors[i]
+		if got.Index != e.index {
+			t.Errorf("Error %d: expected route index %d, got %d", i, e.index, got.Index)
+		}
+		if got.Method != routes[e.index].Method || got.Path != routes[e.index].Path {
+			t.Errorf("Error %d: expected %s %s, got %s %s", i, routes[e.index].Method, routes[e.index].Path, got.Method, got.Path)
+		}
+		if !strings.Contains(got.Message, e.message) {
+			t.Errorf("Error %d: expected message containing %q, got %q", i, e.message, got.Message)
+		}
+	}
+}
+
+func TestValidateRoutes_Valid(t *testing.T) {
+	routes := []specgen.Route{
+		{Path: "/users", Method: "GET", Request: struct{}{}, Responses: []specgen.RouteResponse{{StatusCode: 200}}},
+		{Path: "/users", Method: "POST", Request: struct{}{}, Responses: []specgen.RouteResponse{{StatusCode: 201}}},
+		{
+			Path:   "/users/{id}",
+			Method: "DELETE",
+			Request: struct {
+				ID int `path:"id"`
+			}{},
+			Responses: []specgen.RouteResponse{{StatusCode: 204}},
+		},
+	}
+
+	if err := specgen.ValidateRoutes(routes); err != nil {
+		t.Errorf("Expected no validation error, got: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package specgen
+
+import (
+	"reflect"
+)
+
+// ValidationAdapter feeds the validation rules of a field into the same pipeline as
+// `validate` tags. parent is the struct type declaring the field. ok is false when the
+// adapter has no rules for the field, so the next adapter is tried.
+type ValidationAdapter interface {
+	ParseField(parent reflect.Type, field reflect.StructField) (info ValidationInfo, ok bool)
+}
+
+// ValidationAdapterFunc allows a plain function to be used as a ValidationAdapter.
+type ValidationAdapterFunc func(parent reflect.Type, field reflect.StructField) (ValidationInfo, bool)
+
+func (f ValidationAdapterFunc) ParseField(parent reflect.Type, field reflect.StructField) (ValidationInfo, bool) {
+	return f(parent, field)
+}
+
+// TagAdapter parses go-playground validator v10 rules from the given struct tag key.
+func TagAdapter(tagKey string) ValidationAdapter {
+	return ValidationAdapterFunc(func(_ reflect.Type, field reflect.StructField) (ValidationInfo, bool) {
+		tag, ok := field.Tag.Lookup(tagKey)
+		if !ok {
+			return ValidationInfo{}, false
+		}
+		return ParseValidatorV10Tag(tag), true
+	})
+}
+
+// GinBindingAdapter parses the `binding` tag used by Gin, which has validator v10 syntax.
+func GinBindingAdapter() ValidationAdapter {
+	return TagAdapter("binding")
+}
+
+const defaultValidationTagKey = "validate"
+
+// parseField returns the rules of the first adapter that knows the field, falling back
+// to validator v10 rules in the TagKey tag.
+func (c ValidatorConfig) parseField(parent reflect.Type, field reflect.StructField) ValidationInfo {
+	for _, adapter := range c.Adapters {
+		if info, ok := adapter.ParseField(parent, field); ok {
+			return info
+		}
+	}
+
+	tagKey := c.TagKey
+	if tagKey == "" {
+		tagKey = defaultValidationTagKey
+	}
+	return ParseValidatorV10Tag(field.Tag.Get(tagKey))
+}
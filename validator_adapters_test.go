@@ -0,0 +1,156 @@
+package specgen_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type GinLoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+	Remember bool   `json:"remember" validate:"required"`
+}
+
+type RulesRequest struct {
+	Name string `json:"name" rules:"required,max=50"`
+}
+
+// OzzoStyleRequest has its rules declared in code, as with ozzo-validation.
+type OzzoStyleRequest struct {
+	Code string `json:"code"`
+}
+
+type AdapterSchema struct {
+	Required   []string `yaml:"required"`
+	Properties map[string]struct {
+		Format    string `yaml:"format"`
+		MinLength *int64 `yaml:"minLength"`
+		MaxLength *int64 `yaml:"maxLength"`
+		Enum      []any  `yaml:"enum"`
+	} `yaml:"properties"`
+}
+
+func adapterSchema(t *testing.T, config specgen.ValidatorConfig, request any, name string) AdapterSchema {
+	t.Helper()
+
+	routes := []specgen.Route{
+		{
+			Path:    "/adapters",
+			Method:  "POST",
+			Request: request,
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{Validator: config}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]AdapterSchema `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+	return spec.Components.Schemas[name]
+}
+
+func TestValidatorConfig_GinBindingAdapter(t *testing.T) {
+	config := specgen.ValidatorConfig{Adapters: []specgen.ValidationAdapter{specgen.GinBindingAdapter()}}
+	schema := adapterSchema(t, config, GinLoginRequest{}, "GoSpecgenTestGinLoginRequest")
+
+	if len(schema.Required) != 3 {
+		t.Errorf("Expected binding and validate fields to be required, got: %v", schema.Required)
+	}
+	if schema.Properties["email"].Format != "email" {
+		t.Errorf("Expected email format from binding tag, got: %+v", schema.Properties["email"])
+	}
+	if password := schema.Properties["password"]; password.MinLength == nil || *password.MinLength != 8 {
+		t.Errorf("Expected password minLength 8 from binding tag, got: %+v", password)
+	}
+}
+
+func TestValidatorConfig_TagKey(t *testing.T) {
+	schema := adapterSchema(t, specgen.ValidatorConfig{TagKey: "rules"}, RulesRequest{}, "GoSpecgenTestRulesRequest")
+
+	if len(schema.Required) != 1 || schema.Required[0] != "name" {
+		t.Errorf("Expected name to be required, got: %v", schema.Required)
+	}
+	if name := schema.Properties["name"]; name.MaxLength == nil || *name.MaxLength != 50 {
+		t.Errorf("Expected name maxLength 50 from rules tag, got: %+v", name)
+	}
+}
+
+func TestValidatorConfig_AdapterFunc(t *testing.T) {
+	rules := map[reflect.Type]map[string]specgen.ValidationInfo{
+		reflect.TypeOf(OzzoStyleRequest{}): {
+			"Code": {Required: true, OneOf: []string{"A", "B"}},
+		},
+	}
+	adapter := specgen.ValidationAdapterFunc(func(parent reflect.Type, field reflect.StructField) (specgen.ValidationInfo, bool) {
+		info, ok := rules[parent][field.Name]
+		return info, ok
+	})
+
+	config := specgen.ValidatorConfig{Adapters: []specgen.ValidationAdapter{adapter}}
+	schema := adapterSchema(t, config, OzzoStyleRequest{}, "GoSpecgenTestOzzoStyleRequest")
+
+	if len(schema.Required) != 1 || schema.Required[0] != "code" {
+		t.Errorf("Expected code to be required, got: %v", schema.Required)
+	}
+	if code := schema.Properties["code"]; len(code.Enum) != 2 {
+		t.Errorf("Expected code enum from adapter, got: %+v", code)
+	}
+}
+
+type GinSearchRequest struct {
+	Query  string `query:"q" binding:"required"`
+	Cursor string `query:"cursor" rules:"required"`
+	Page   int    `query:"page" validate:"required"`
+}
+
+func TestValidatorConfig_RequiredParams(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:      "/search",
+			Method:    "GET",
+			Request:   GinSearchRequest{},
+			Responses: []specgen.RouteResponse{{StatusCode: 204}},
+		},
+	}
+	config := specgen.ValidatorConfig{TagKey: "rules", Adapters: []specgen.ValidationAdapter{specgen.GinBindingAdapter()}}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{Validator: config}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name     string `yaml:"name"`
+				Required bool   `yaml:"required"`
+			} `yaml:"parameters"`
+		} `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	required := make(map[string]bool)
+	for _, param := range spec.Paths["/search"]["get"].Parameters {
+		required[param.Name] = param.Required
+	}
+	want := map[string]bool{"q": true, "cursor": true, "page": false}
+	if !reflect.DeepEqual(required, want) {
+		t.Errorf("Expected parameters required by the configured adapter and tag key %v, got %v", want, required)
+	}
+}
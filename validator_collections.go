@@ -0,0 +1,117 @@
+package specgen
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// applyCollectionRules maps length and uniqueness rules to array or map keywords.
+// Validator v10 evaluates min, max, len, eq, ne, gt, gte, lt and lte on the number of
+// items of slices and maps.
+func applyCollectionRules(schema *jsonschema.Schema, info ValidationInfo) {
+	minLen, maxLen := lengthBounds(info)
+	if info.Eq != nil {
+		if length, err := strconv.ParseInt(*info.Eq, 10, 64); err == nil {
+			minLen, maxLen = &length, &length
+		}
+	}
+	isArray := schema.HasType(jsonschema.Array)
+
+	if isArray {
+		if minLen != nil {
+			schema.MinItems = *minLen
+		}
+		if maxLen != nil {
+			schema.MaxItems = maxLen
+		}
+	} else {
+		if minLen != nil {
+			schema.MinProperties = *minLen
+		}
+		if maxLen != nil {
+			schema.MaxProperties = maxLen
+		}
+	}
+
+	if info.Ne != nil {
+		if length, err := strconv.ParseInt(*info.Ne, 10, 64); err == nil {
+			not := &jsonschema.Schema{}
+			if isArray {
+				not.WithMinItems(length).WithMaxItems(length)
+			} else {
+				not.WithMinProperties(length).WithMaxProperties(length)
+			}
+			addNot(schema, not.ToSchemaOrBool())
+		}
+	}
+
+	if info.Unique {
+		if isArray {
+			schema.WithUniqueItems(true)
+		} else {
+			// OpenAPI has no keyword for unique map values
+			schema.WithExtraPropertiesItem("x-unique-values", true)
+		}
+	}
+}
+
+// lengthBounds returns the minimum and maximum length of a string or collection.
+// len fixes both bounds, gt and lt are converted to inclusive bounds.
+func lengthBounds(info ValidationInfo) (minLen, maxLen *int64) {
+	if info.Len != nil {
+		length := *info.Len
+		return &length, &length
+	}
+
+	switch {
+	case info.Min != nil:
+		minLen = ptrTo(int64(*info.Min))
+	case info.Gte != nil:
+		minLen = ptrTo(int64(*info.Gte))
+	case info.Gt != nil:
+		minLen = ptrTo(int64(*info.Gt) + 1)
+	}
+
+	switch {
+	case info.Max != nil:
+		maxLen = ptrTo(int64(*info.Max))
+	case info.Lte != nil:
+		maxLen = ptrTo(int64(*info.Lte))
+	case info.Lt != nil && *info.Lt >= 1:
+		maxLen = ptrTo(int64(*info.Lt) - 1)
+	}
+
+	return minLen, maxLen
+}
+
+// applyUniqueBy documents unique=Field on a slice of structs as the x-unique-by
+// extension holding the property name of the field.
+func applyUniqueBy(schema *jsonschema.Schema, typ reflect.Type, fieldName, propertyTag string) {
+	if !schema.HasType(jsonschema.Array) {
+		return
+	}
+
+	name := fieldName
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		elem := typ.Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Struct {
+			if field, ok := elem.FieldByName(fieldName); ok {
+				name = propertyName(field, propertyTag)
+			}
+		}
+	}
+
+	schema.WithExtraPropertiesItem("x-unique-by", name)
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
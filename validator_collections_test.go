@@ -0,0 +1,110 @@
+package specgen_test
+
+import (
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type BatchItem struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+type BatchRequest struct {
+	Items    []BatchItem       `json:"items" validate:"min=1,max=100,unique=SKU"`
+	IDs      []string          `json:"ids" validate:"unique,gt=0,lt=51"`
+	Pair     [2]int            `json:"pair" validate:"len=2"`
+	Quotas   map[string]int    `json:"quotas" validate:"min=1,max=10"`
+	Headers  map[string]string `json:"headers" validate:"len=3"`
+	Owners   map[string]string `json:"owners" validate:"unique,ne=0"`
+	Name     string            `json:"name" validate:"gte=2,lte=20"`
+	Nickname string            `json:"nickname" validate:"eq=bob"`
+}
+
+type CollectionSchema struct {
+	MinItems      *int64            `yaml:"minItems"`
+	MaxItems      *int64            `yaml:"maxItems"`
+	UniqueItems   bool              `yaml:"uniqueItems"`
+	MinProperties *int64            `yaml:"minProperties"`
+	MaxProperties *int64            `yaml:"maxProperties"`
+	MinLength     *int64            `yaml:"minLength"`
+	MaxLength     *int64            `yaml:"maxLength"`
+	Enum          []any             `yaml:"enum"`
+	UniqueBy      string            `yaml:"x-unique-by"`
+	UniqueValues  bool              `yaml:"x-unique-values"`
+	Not           *CollectionSchema `yaml:"not"`
+}
+
+func TestGenerateOpenAPISpec_ValidatorCollections(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/batches",
+			Method:  "POST",
+			Request: BatchRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]CollectionSchema `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	props := spec.Components.Schemas["GoSpecgenTestBatchRequest"].Properties
+
+	items := props["items"]
+	if items.MinItems == nil || *items.MinItems != 1 || items.MaxItems == nil || *items.MaxItems != 100 {
+		t.Errorf("Expected items minItems 1 and maxItems 100, got: %+v", items)
+	}
+	if items.UniqueItems || items.UniqueBy != "sku" {
+		t.Errorf("Expected unique=SKU to set x-unique-by: sku without uniqueItems, got: %+v", items)
+	}
+
+	ids := props["ids"]
+	if !ids.UniqueItems || ids.MinItems == nil || *ids.MinItems != 1 || ids.MaxItems == nil || *ids.MaxItems != 50 {
+		t.Errorf("Expected ids uniqueItems with 1 to 50 items, got: %+v", ids)
+	}
+
+	if pair := props["pair"]; pair.MinItems == nil || *pair.MinItems != 2 || pair.MaxItems == nil || *pair.MaxItems != 2 {
+		t.Errorf("Expected len=2 to set minItems and maxItems, got: %+v", pair)
+	}
+
+	quotas := props["quotas"]
+	if quotas.MinProperties == nil || *quotas.MinProperties != 1 || quotas.MaxProperties == nil || *quotas.MaxProperties != 10 {
+		t.Errorf("Expected quotas minProperties 1 and maxProperties 10, got: %+v", quotas)
+	}
+
+	headers := props["headers"]
+	if headers.MinProperties == nil || *headers.MinProperties != 3 || headers.MaxProperties == nil || *headers.MaxProperties != 3 {
+		t.Errorf("Expected len=3 to set minProperties and maxProperties, got: %+v", headers)
+	}
+
+	owners := props["owners"]
+	if !owners.UniqueValues || owners.Not == nil || owners.Not.MaxProperties == nil || *owners.Not.MaxProperties != 0 {
+		t.Errorf("Expected owners x-unique-values and not maxProperties 0, got: %+v", owners)
+	}
+
+	name := props["name"]
+	if name.MinLength == nil || *name.MinLength != 2 || name.MaxLength == nil || *name.MaxLength != 20 {
+		t.Errorf("Expected gte/lte to set string length, got: %+v", name)
+	}
+
+	if nickname := props["nickname"]; nickname.MinLength != nil || len(nickname.Enum) != 1 || nickname.Enum[0] != "bob" {
+		t.Errorf("eq on a string compares the value, got: %+v", nickname)
+	}
+}
@@ -0,0 +1,79 @@
+package specgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// FieldComparison is a cross-field validator such as gtfield=StartDate.
+type FieldComparison struct {
+	Rule  string
+	Field string
+}
+
+type fieldComparisonRule struct {
+	operator    string
+	description string
+}
+
+var fieldComparisonRules = map[string]fieldComparisonRule{
+	"eqfield":       {operator: "==", description: "Must be equal to %s."},
+	"nefield":       {operator: "!=", description: "Must not be equal to %s."},
+	"gtfield":       {operator: ">", description: "Must be greater than %s."},
+	"gtefield":      {operator: ">=", description: "Must be greater than or equal to %s."},
+	"ltfield":       {operator: "<", description: "Must be less than %s."},
+	"ltefield":      {operator: "<=", description: "Must be less than or equal to %s."},
+	"eqcsfield":     {operator: "==", description: "Must be equal to %s."},
+	"necsfield":     {operator: "!=", description: "Must not be equal to %s."},
+	"gtcsfield":     {operator: ">", description: "Must be greater than %s."},
+	"gtecsfield":    {operator: ">=", description: "Must be greater than or equal to %s."},
+	"ltcsfield":     {operator: "<", description: "Must be less than %s."},
+	"ltecsfield":    {operator: "<=", description: "Must be less than or equal to %s."},
+	"fieldcontains": {operator: "contains", description: "Must contain the value of %s."},
+	"fieldexcludes": {operator: "excludes", description: "Must not contain the value of %s."},
+}
+
+func parseFieldComparison(info *ValidationInfo, part string) bool {
+	rule, field, ok := strings.Cut(part, "=")
+	if _, known := fieldComparisonRules[rule]; !ok || !known || field == "" {
+		return false
+	}
+
+	info.FieldComparisons = append(info.FieldComparisons, FieldComparison{Rule: rule, Field: field})
+	return true
+}
+
+// applyFieldComparisons documents cross-field comparisons on the property, since JSON Schema
+// cannot compare two values. The x-field-comparisons extension lists each comparison for
+// tooling and a sentence per rule is appended to the description for readers.
+func applyFieldComparisons(schema, parent *jsonschema.Schema, comparisons []FieldComparison, propertyTag string) {
+	extension := make([]map[string]string, 0, len(comparisons))
+	sentences := make([]string, 0, len(comparisons))
+
+	for _, comparison := range comparisons {
+		rule := fieldComparisonRules[comparison.Rule]
+
+		// Fields of the same struct are referenced by property name, cross-struct paths stay as they are
+		name := comparison.Field
+		if field, ok := structField(parent, comparison.Field); ok && !strings.HasSuffix(comparison.Rule, "csfield") {
+			name = propertyName(field, propertyTag)
+		}
+
+		extension = append(extension, map[string]string{
+			"rule":     comparison.Rule,
+			"operator": rule.operator,
+			"field":    name,
+		})
+		sentences = append(sentences, fmt.Sprintf(rule.description, "`"+name+"`"))
+	}
+
+	schema.WithExtraPropertiesItem("x-field-comparisons", extension)
+
+	description := strings.Join(sentences, " ")
+	if schema.Description != nil && *schema.Description != "" {
+		description = *schema.Description + " " + description
+	}
+	schema.WithDescription(description)
+}
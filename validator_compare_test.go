@@ -0,0 +1,100 @@
+package specgen_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type BookingRequest struct {
+	StartDate       time.Time `json:"start_date" validate:"required"`
+	EndDate         time.Time `json:"end_date" validate:"required,gtfield=StartDate" description:"Last night of the stay."`
+	Password        string    `json:"password" validate:"required"`
+	PasswordConfirm string    `json:"password_confirm" validate:"eqfield=Password"`
+	Guests          int       `json:"guests" validate:"gtefield=Adults,ltefield=Rooms"`
+	Adults          int       `json:"adults"`
+	Rooms           int       `json:"rooms"`
+	Username        string    `json:"username" validate:"nefield=Password"`
+}
+
+type FieldComparisonSchema struct {
+	Description string              `yaml:"description"`
+	Comparisons []map[string]string `yaml:"x-field-comparisons"`
+}
+
+func TestParseValidatorV10Tag_FieldComparisons(t *testing.T) {
+	info := specgen.ParseValidatorV10Tag("required,gtfield=StartDate,necsfield=Inner.Field")
+
+	if len(info.FieldComparisons) != 2 {
+		t.Fatalf("Expected 2 field comparisons, got: %+v", info.FieldComparisons)
+	}
+	if info.FieldComparisons[0] != (specgen.FieldComparison{Rule: "gtfield", Field: "StartDate"}) {
+		t.Errorf("Unexpected comparison: %+v", info.FieldComparisons[0])
+	}
+	if info.FieldComparisons[1] != (specgen.FieldComparison{Rule: "necsfield", Field: "Inner.Field"}) {
+		t.Errorf("Unexpected comparison: %+v", info.FieldComparisons[1])
+	}
+}
+
+func TestGenerateOpenAPISpec_FieldComparisons(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/bookings",
+			Method:  "POST",
+			Request: BookingRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]FieldComparisonSchema `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	props := spec.Components.Schemas["GoSpecgenTestBookingRequest"].Properties
+
+	endDate := props["end_date"]
+	if len(endDate.Comparisons) != 1 {
+		t.Fatalf("Expected one comparison on end_date, got: %v", endDate.Comparisons)
+	}
+	expected := map[string]string{"rule": "gtfield", "operator": ">", "field": "start_date"}
+	for key, value := range expected {
+		if endDate.Comparisons[0][key] != value {
+			t.Errorf("Expected end_date comparison %s=%s, got: %v", key, value, endDate.Comparisons[0])
+		}
+	}
+	if endDate.Description != "Last night of the stay. Must be greater than `start_date`." {
+		t.Errorf("Unexpected end_date description: %q", endDate.Description)
+	}
+
+	if confirm := props["password_confirm"]; confirm.Description != "Must be equal to `password`." {
+		t.Errorf("Unexpected password_confirm description: %q", confirm.Description)
+	}
+
+	guests := props["guests"]
+	if len(guests.Comparisons) != 2 || guests.Comparisons[1]["field"] != "rooms" {
+		t.Errorf("Expected two comparisons on guests, got: %v", guests.Comparisons)
+	}
+	if guests.Description != "Must be greater than or equal to `adults`. Must be less than or equal to `rooms`." {
+		t.Errorf("Unexpected guests description: %q", guests.Description)
+	}
+
+	if len(props["start_date"].Comparisons) != 0 {
+		t.Error("start_date should not have comparisons")
+	}
+}
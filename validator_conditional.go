@@ -0,0 +1,186 @@
+package specgen
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// ConditionalRule is a cross-field requirement such as required_if=Status active.
+// Params hold Go field names, or field name and value pairs for *_if and *_unless rules.
+type ConditionalRule struct {
+	Rule   string
+	Params []string
+}
+
+var conditionalRules = map[string]bool{
+	"required_if":          true,
+	"required_unless":      true,
+	"required_with":        true,
+	"required_with_all":    true,
+	"required_without":     true,
+	"required_without_all": true,
+	"excluded_if":          true,
+	"excluded_unless":      true,
+	"excluded_with":        true,
+	"excluded_with_all":    true,
+	"excluded_without":     true,
+	"excluded_without_all": true,
+}
+
+func parseConditionalRule(info *ValidationInfo, part string) bool {
+	rule, param, ok := strings.Cut(part, "=")
+	if !ok || !conditionalRules[rule] {
+		return false
+	}
+
+	info.Conditions = append(info.Conditions, ConditionalRule{Rule: rule, Params: strings.Fields(param)})
+	return true
+}
+
+// applyConditionalRules adds one allOf entry per rule to the parent schema. Each rule
+// "condition implies required" is written as anyOf [not condition, required], and
+// "condition implies excluded" as not [condition and present], which OpenAPI 3.0 supports
+// without if/then/else or dependentRequired.
+//
+// Validator v10 checks for non-zero values while the schema can only check presence,
+// so the generated composition is an approximation for fields that may be sent empty.
+func applyConditionalRules(parent *jsonschema.Schema, name string, rules []ConditionalRule, propertyTag string) {
+	for _, rule := range rules {
+		present := requiredSchema(name)
+
+		var entry jsonschema.Schema
+		switch rule.Rule {
+		case "required_if":
+			entry.WithAnyOf(notSchema(valuesMatch(parent, rule.Params, propertyTag)), present)
+		case "required_unless":
+			entry.WithAnyOf(valuesMatch(parent, rule.Params, propertyTag), present)
+		case "required_with":
+			entry.WithAnyOf(notSchema(anyPresent(parent, rule.Params, propertyTag)), present)
+		case "required_with_all":
+			entry.WithAnyOf(notSchema(requiredSchema(propertyNames(parent, rule.Params, propertyTag)...)), present)
+		case "required_without":
+			entry.WithAnyOf(requiredSchema(propertyNames(parent, rule.Params, propertyTag)...), present)
+		case "required_without_all":
+			entry.WithAnyOf(anyPresent(parent, rule.Params, propertyTag), present)
+		case "excluded_if":
+			entry.WithNot(allOfSchema(valuesMatch(parent, rule.Params, propertyTag), present))
+		case "excluded_unless":
+			entry.WithAnyOf(valuesMatch(parent, rule.Params, propertyTag), notSchema(present))
+		case "excluded_with":
+			entry.WithNot(allOfSchema(anyPresent(parent, rule.Params, propertyTag), present))
+		case "excluded_with_all":
+			entry.WithNot(requiredSchema(append(propertyNames(parent, rule.Params, propertyTag), name)...))
+		case "excluded_without":
+			entry.WithAnyOf(requiredSchema(propertyNames(parent, rule.Params, propertyTag)...), notSchema(present))
+		case "excluded_without_all":
+			entry.WithAnyOf(anyPresent(parent, rule.Params, propertyTag), notSchema(present))
+		default:
+			continue
+		}
+
+		parent.AllOf = append(parent.AllOf, entry.ToSchemaOrBool())
+	}
+}
+
+func requiredSchema(names ...string) jsonschema.SchemaOrBool {
+	return (&jsonschema.Schema{}).WithRequired(names...).ToSchemaOrBool()
+}
+
+func notSchema(schema jsonschema.SchemaOrBool) jsonschema.SchemaOrBool {
+	return (&jsonschema.Schema{}).WithNot(schema).ToSchemaOrBool()
+}
+
+func allOfSchema(schemas ...jsonschema.SchemaOrBool) jsonschema.SchemaOrBool {
+	return (&jsonschema.Schema{}).WithAllOf(schemas...).ToSchemaOrBool()
+}
+
+func anyPresent(parent *jsonschema.Schema, fieldNames []string, propertyTag string) jsonschema.SchemaOrBool {
+	names := propertyNames(parent, fieldNames, propertyTag)
+	if len(names) == 1 {
+		return requiredSchema(names[0])
+	}
+
+	schemas := make([]jsonschema.SchemaOrBool, 0, len(names))
+	for _, name := range names {
+		schemas = append(schemas, requiredSchema(name))
+	}
+	return (&jsonschema.Schema{}).WithAnyOf(schemas...).ToSchemaOrBool()
+}
+
+// valuesMatch matches when every field and value pair in params holds.
+func valuesMatch(parent *jsonschema.Schema, params []string, propertyTag string) jsonschema.SchemaOrBool {
+	schema := jsonschema.Schema{}
+	for i := 0; i+1 < len(params); i += 2 {
+		field, ok := structField(parent, params[i])
+		name := params[i]
+		var value any = params[i+1]
+		if ok {
+			name = propertyName(field, propertyTag)
+			value = typedValue(field.Type, params[i+1])
+		}
+
+		schema.WithPropertiesItem(name, (&jsonschema.Schema{}).WithEnum(value).ToSchemaOrBool())
+		schema.Required = append(schema.Required, name)
+	}
+	return schema.ToSchemaOrBool()
+}
+
+func propertyNames(parent *jsonschema.Schema, fieldNames []string, propertyTag string) []string {
+	names := make([]string, 0, len(fieldNames))
+	for _, fieldName := range fieldNames {
+		if field, ok := structField(parent, fieldName); ok {
+			names = append(names, propertyName(field, propertyTag))
+		} else {
+			names = append(names, fieldName)
+		}
+	}
+	return names
+}
+
+func structField(parent *jsonschema.Schema, fieldName string) (reflect.StructField, bool) {
+	typ := parent.ReflectType
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+	return typ.FieldByName(fieldName)
+}
+
+func propertyName(field reflect.StructField, propertyTag string) string {
+	if name := tagName(field.Tag.Get(propertyTag)); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+// typedValue converts a validator parameter to the JSON type of the referenced field.
+func typedValue(typ reflect.Type, value string) any {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Float32, reflect.Float64:
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	}
+	return value
+}
@@ -0,0 +1,117 @@
+package specgen_test
+
+import (
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type PaymentRequest struct {
+	Method     string `json:"method" validate:"required,oneof=card bank"`
+	Amount     int    `json:"amount"`
+	CardNumber string `json:"card_number" validate:"required_if=Method card"`
+	IBAN       string `json:"iban" validate:"required_unless=Method card,excluded_with=CardNumber"`
+	Reference  string `json:"reference" validate:"required_without_all=CardNumber IBAN"`
+	Note       string `json:"note" validate:"excluded_if=Amount 0"`
+}
+
+type CompositionSchema struct {
+	Required   []string                     `yaml:"required"`
+	Properties map[string]CompositionSchema `yaml:"properties"`
+	Enum       []any                        `yaml:"enum"`
+	AllOf      []CompositionSchema          `yaml:"allOf"`
+	AnyOf      []CompositionSchema          `yaml:"anyOf"`
+	Not        *CompositionSchema           `yaml:"not"`
+}
+
+func TestParseValidatorV10Tag_Conditional(t *testing.T) {
+	info := specgen.ParseValidatorV10Tag("required_if=Method card Currency EUR,excluded_with=A B")
+
+	if info.Required {
+		t.Error("Conditional rules should not mark the field as required")
+	}
+	if len(info.Conditions) != 2 {
+		t.Fatalf("Expected 2 conditions, got: %+v", info.Conditions)
+	}
+	if info.Conditions[0].Rule != "required_if" || len(info.Conditions[0].Params) != 4 {
+		t.Errorf("Unexpected required_if condition: %+v", info.Conditions[0])
+	}
+	if info.Conditions[1].Rule != "excluded_with" || len(info.Conditions[1].Params) != 2 {
+		t.Errorf("Unexpected excluded_with condition: %+v", info.Conditions[1])
+	}
+}
+
+func TestGenerateOpenAPISpec_ConditionalRequired(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/payments",
+			Method:  "POST",
+			Request: PaymentRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]CompositionSchema `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	schema := spec.Components.Schemas["GoSpecgenTestPaymentRequest"]
+	if len(schema.Required) != 1 || schema.Required[0] != "method" {
+		t.Errorf("Expected only method to be unconditionally required, got: %v", schema.Required)
+	}
+	if len(schema.AllOf) != 5 {
+		t.Fatalf("Expected 5 conditional entries, got %d", len(schema.AllOf))
+	}
+
+	// required_if=Method card: method is not card, or card_number is present
+	requiredIf := schema.AllOf[0]
+	if len(requiredIf.AnyOf) != 2 || requiredIf.AnyOf[0].Not == nil {
+		t.Fatalf("Unexpected required_if composition: %+v", requiredIf)
+	}
+	if enum := requiredIf.AnyOf[0].Not.Properties["method"].Enum; len(enum) != 1 || enum[0] != "card" {
+		t.Errorf("Expected required_if condition on method=card, got: %v", enum)
+	}
+	if required := requiredIf.AnyOf[1].Required; len(required) != 1 || required[0] != "card_number" {
+		t.Errorf("Expected card_number to be required, got: %v", required)
+	}
+
+	// required_unless=Method card: method is card, or iban is present
+	requiredUnless := schema.AllOf[1]
+	if len(requiredUnless.AnyOf) != 2 || requiredUnless.AnyOf[0].Properties["method"].Enum[0] != "card" {
+		t.Errorf("Unexpected required_unless composition: %+v", requiredUnless)
+	}
+
+	// excluded_with=CardNumber: not both card_number and iban
+	excludedWith := schema.AllOf[2]
+	if excludedWith.Not == nil || len(excludedWith.Not.AllOf) != 2 || excludedWith.Not.AllOf[0].Required[0] != "card_number" {
+		t.Errorf("Unexpected excluded_with composition: %+v", excludedWith)
+	}
+
+	// required_without_all=CardNumber IBAN: card_number, iban or reference is present
+	requiredWithoutAll := schema.AllOf[3]
+	if len(requiredWithoutAll.AnyOf) != 2 || len(requiredWithoutAll.AnyOf[0].AnyOf) != 2 {
+		t.Errorf("Unexpected required_without_all composition: %+v", requiredWithoutAll)
+	}
+
+	// excluded_if=Amount 0 uses the integer type of amount
+	excludedIf := schema.AllOf[4]
+	if excludedIf.Not == nil || len(excludedIf.Not.AllOf) != 2 {
+		t.Fatalf("Unexpected excluded_if composition: %+v", excludedIf)
+	}
+	if enum := excludedIf.Not.AllOf[0].Properties["amount"].Enum; len(enum) != 1 || enum[0] != 0 {
+		t.Errorf("Expected excluded_if condition on amount=0 as integer, got: %#v", enum)
+	}
+}
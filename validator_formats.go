@@ -0,0 +1,80 @@
+package specgen
+
+import "strings"
+
+//go:generate go test -run TestValidatorFormatsDoc -update .
+
+type FormatMapping struct {
+	Tag         string
+	Format      string
+	Description string
+}
+
+// validatorFormats is the single source of the validator to format mapping, and of the
+// table in VALIDATOR.md.
+var validatorFormats = []FormatMapping{
+	{Tag: "email", Format: "email", Description: "Must be a valid email format."},
+	{Tag: "url", Format: "uri", Description: "Must be a valid URI (URL)."},
+	{Tag: "uri", Format: "uri", Description: "Must be a valid URI."},
+	{Tag: "http_url", Format: "uri", Description: "Must be a valid HTTP or HTTPS URL."},
+	{Tag: "uuid", Format: "uuid", Description: "Must be a valid UUID."},
+	{Tag: "uuid3", Format: "uuid", Description: "Must be a valid version 3 UUID."},
+	{Tag: "uuid4", Format: "uuid", Description: "Must be a valid version 4 UUID."},
+	{Tag: "uuid5", Format: "uuid", Description: "Must be a valid version 5 UUID."},
+	{Tag: "ulid", Format: "ulid", Description: "Must be a valid ULID."},
+	{Tag: "datetime", Format: "date-time", Description: "Must be a date and time string adhering to RFC 3339."},
+	{Tag: "ip", Format: "ip", Description: "Must be an IPv4 or IPv6 address."},
+	{Tag: "ipv4", Format: "ipv4", Description: "Must be an IPv4 address."},
+	{Tag: "ipv6", Format: "ipv6", Description: "Must be an IPv6 address."},
+	{Tag: "cidr", Format: "cidr", Description: "Must be an IPv4 or IPv6 CIDR block."},
+	{Tag: "cidrv4", Format: "cidrv4", Description: "Must be an IPv4 CIDR block."},
+	{Tag: "cidrv6", Format: "cidrv6", Description: "Must be an IPv6 CIDR block."},
+	{Tag: "hostname", Format: "hostname", Description: "Must be a hostname (RFC 952)."},
+	{Tag: "hostname_rfc1123", Format: "hostname", Description: "Must be a hostname (RFC 1123)."},
+	{Tag: "fqdn", Format: "hostname", Description: "Must be a fully qualified domain name."},
+	{Tag: "mac", Format: "mac", Description: "Must be a MAC address."},
+	{Tag: "isbn", Format: "isbn", Description: "Must be an ISBN-10 or ISBN-13."},
+	{Tag: "isbn10", Format: "isbn10", Description: "Must be an ISBN-10."},
+	{Tag: "isbn13", Format: "isbn13", Description: "Must be an ISBN-13."},
+	{Tag: "json", Format: "json", Description: "Must be a JSON encoded string."},
+	{Tag: "jwt", Format: "jwt", Description: "Must be a JSON Web Token."},
+	{Tag: "semver", Format: "semver", Description: "Must be a semantic version (2.0.0)."},
+	{Tag: "iso3166_1_alpha2", Format: "iso3166-1-alpha-2", Description: "Must be an ISO 3166-1 alpha-2 country code."},
+	{Tag: "iso3166_1_alpha3", Format: "iso3166-1-alpha-3", Description: "Must be an ISO 3166-1 alpha-3 country code."},
+	{Tag: "iso3166_1_alpha_numeric", Format: "iso3166-1-numeric", Description: "Must be an ISO 3166-1 numeric country code."},
+	{Tag: "bcp47_language_tag", Format: "bcp47", Description: "Must be a BCP 47 language tag."},
+	{Tag: "datetime=2006-01-02", Format: "date", Description: "A date layout maps to a full-date string."},
+	{Tag: "datetime=15:04:05", Format: "time", Description: "A time layout maps to a partial-time string."},
+	{Tag: "datetime=2006-01-02T15:04:05Z07:00", Format: "date-time", Description: "An RFC 3339 layout maps to a date-time string."},
+}
+
+var validatorFormatsByTag = func() map[string]string {
+	formats := make(map[string]string, len(validatorFormats))
+	for _, mapping := range validatorFormats {
+		formats[mapping.Tag] = mapping.Format
+	}
+	return formats
+}()
+
+// ValidatorFormats returns the validator tags that map to an OpenAPI format.
+func ValidatorFormats() []FormatMapping {
+	formats := make([]FormatMapping, len(validatorFormats))
+	copy(formats, validatorFormats)
+	return formats
+}
+
+// parseFormat reports whether part is a format validator and records its format. Datetime
+// layouts without a matching format are kept as the x-datetime-layout extension.
+func parseFormat(info *ValidationInfo, part string) bool {
+	if format, ok := validatorFormatsByTag[part]; ok {
+		info.Format = format
+		return true
+	}
+
+	if layout, ok := strings.CutPrefix(part, "datetime="); ok && layout != "" {
+		info.DatetimeLayout = layout
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package specgen_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+var update = flag.Bool("update", false, "update generated documentation")
+
+const (
+	formatsStart = "<!-- formats:start -->\n"
+	formatsEnd   = "<!-- formats:end -->"
+)
+
+func formatsTable() string {
+	var b strings.Builder
+	b.WriteString("| Go Validator Tag | OpenAPI Schema Keyword | Description |\n")
+	b.WriteString("| :--------------- | :--------------------- | :---------- |\n")
+	for _, mapping := range specgen.ValidatorFormats() {
+		fmt.Fprintf(&b, "| `%s` | `format: %s` | %s |\n", mapping.Tag, mapping.Format, mapping.Description)
+	}
+	return b.String()
+}
+
+func TestValidatorFormatsDoc(t *testing.T) {
+	content, err := os.ReadFile("VALIDATOR.md")
+	if err != nil {
+		t.Fatalf("Failed to read VALIDATOR.md: %v", err)
+	}
+
+	doc := string(content)
+	start := strings.Index(doc, formatsStart)
+	end := strings.Index(doc, formatsEnd)
+	if start < 0 || end < start {
+		t.Fatal("VALIDATOR.md is missing the formats markers")
+	}
+
+	current := doc[start+len(formatsStart) : end]
+	expected := formatsTable()
+	if current == expected {
+		return
+	}
+
+	if !*update {
+		t.Fatal("VALIDATOR.md format table is out of date, run `go generate`")
+	}
+
+	doc = doc[:start+len(formatsStart)] + expected + doc[end:]
+	if err := os.WriteFile("VALIDATOR.md", []byte(doc), 0644); err != nil {
+		t.Fatalf("Failed to write VALIDATOR.md: %v", err)
+	}
+}
+
+type FormatRequest struct {
+	Address  string `json:"address" validate:"ip"`
+	Network  string `json:"network" validate:"cidrv4"`
+	Host     string `json:"host" validate:"fqdn"`
+	Version  string `json:"version" validate:"semver"`
+	Country  string `json:"country" validate:"iso3166_1_alpha2"`
+	Language string `json:"language" validate:"bcp47_language_tag"`
+	Birthday string `json:"birthday" validate:"datetime=2006-01-02"`
+	Month    string `json:"month" validate:"datetime=2006-01"`
+}
+
+func TestGenerateOpenAPISpec_ValidatorFormats(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/devices",
+			Method:  "POST",
+			Request: FormatRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Format         string `yaml:"format"`
+					DatetimeLayout string `yaml:"x-datetime-layout"`
+				} `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	props := spec.Components.Schemas["GoSpecgenTestFormatRequest"].Properties
+	expected := map[string]string{
+		"address":  "ip",
+		"network":  "cidrv4",
+		"host":     "hostname",
+		"version":  "semver",
+		"country":  "iso3166-1-alpha-2",
+		"language": "bcp47",
+		"birthday": "date",
+		"month":    "",
+	}
+	for name, format := range expected {
+		if props[name].Format != format {
+			t.Errorf("Expected %s format %q, got %q", name, format, props[name].Format)
+		}
+	}
+	if props["month"].DatetimeLayout != "2006-01" {
+		t.Errorf("Expected month x-datetime-layout 2006-01, got %q", props["month"].DatetimeLayout)
+	}
+}
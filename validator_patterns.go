@@ -0,0 +1,82 @@
+package specgen
+
+import (
+	"regexp"
+	"strings"
+)
+
+// stringPatterns maps go-playground string-content validators to ECMA-262 compatible
+// regular expressions. Patterns avoid lookarounds so Go-based spec validators can compile them.
+var stringPatterns = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(?:0[xX])?[0-9a-fA-F]+$`,
+	"lowercase":   `^[^A-Z]+$`,
+	"uppercase":   `^[^a-z]+$`,
+	"e164":        `^\+[1-9]?[0-9]{7,14}$`,
+	"ascii":       `^[\x00-\x7F]*$`,
+	"printascii":  `^[\x20-\x7E]*$`,
+	"base64":      `^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=|[A-Za-z0-9+/]{4})$`,
+	"base64url":   `^(?:[A-Za-z0-9_-]{4})*(?:[A-Za-z0-9_-]{2}==|[A-Za-z0-9_-]{3}=|[A-Za-z0-9_-]{4})$`,
+	"hexcolor":    `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`,
+	"md5":         `^[0-9a-f]{32}$`,
+	"sha256":      `^[0-9a-f]{64}$`,
+	"sha512":      `^[0-9a-f]{128}$`,
+}
+
+// stringParamPatterns builds patterns for parametrized validators such as startswith=abc.
+var stringParamPatterns = map[string]func(param string) string{
+	"startswith":  func(param string) string { return "^" + regexp.QuoteMeta(param) },
+	"endswith":    func(param string) string { return regexp.QuoteMeta(param) + "$" },
+	"contains":    func(param string) string { return regexp.QuoteMeta(param) },
+	"containsany": func(param string) string { return "[" + quoteCharClass(param) + "]" },
+}
+
+// stringNotPatterns builds patterns a value must not match, emitted under `not`.
+var stringNotPatterns = map[string]func(param string) string{
+	"startsnotwith": func(param string) string { return "^" + regexp.QuoteMeta(param) },
+	"endsnotwith":   func(param string) string { return regexp.QuoteMeta(param) + "$" },
+	"excludes":      func(param string) string { return regexp.QuoteMeta(param) },
+	"excludesall":   func(param string) string { return "[" + quoteCharClass(param) + "]" },
+}
+
+// validatorParamReplacer decodes the escapes validator v10 uses for reserved characters in parameters.
+var validatorParamReplacer = strings.NewReplacer("0x2C", ",", "0x7C", "|")
+
+func quoteCharClass(chars string) string {
+	var b strings.Builder
+	for _, c := range chars {
+		if strings.ContainsRune(`\]^-[`, c) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// parseStringPattern reports whether part is a string-content validator and records its pattern.
+func parseStringPattern(info *ValidationInfo, part string) bool {
+	if pattern, ok := stringPatterns[part]; ok {
+		info.Patterns = append(info.Patterns, pattern)
+		return true
+	}
+
+	name, param, ok := strings.Cut(part, "=")
+	if !ok || param == "" {
+		return false
+	}
+	param = validatorParamReplacer.Replace(param)
+
+	if build, ok := stringParamPatterns[name]; ok {
+		info.Patterns = append(info.Patterns, build(param))
+		return true
+	}
+	if build, ok := stringNotPatterns[name]; ok {
+		info.NotPatterns = append(info.NotPatterns, build(param))
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,154 @@
+package specgen
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// applyPresenceSemantics aligns the schema with how validator v10 treats zero values:
+//
+//   - required on a pointer, slice or map only rejects nil, so the schema is not nullable;
+//   - required on any other field rejects the zero value ("", 0, false);
+//   - omitempty skips all other rules for the zero value, so constraints that would
+//     reject it move into anyOf [zero value, constraints].
+//
+// Pointers keep their nullability: validator runs the rules on non-nil values only,
+// which matches how JSON Schema ignores type-specific keywords for null.
+func applyPresenceSemantics(schema *jsonschema.Schema, info ValidationInfo, fieldType reflect.Type) {
+	if schema.Ref != nil {
+		return
+	}
+
+	nilable := false
+	switch fieldType.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		nilable = true
+	}
+
+	if info.Required {
+		if nilable {
+			schema.RemoveType(jsonschema.Null)
+		} else {
+			excludeZeroValue(schema)
+		}
+	}
+
+	if info.OmitEmpty && !info.Required && fieldType.Kind() != reflect.Pointer {
+		allowZeroValue(schema)
+	}
+}
+
+func excludeZeroValue(schema *jsonschema.Schema) {
+	switch {
+	case schema.HasType(jsonschema.String):
+		if schema.MinLength < 1 {
+			schema.MinLength = 1
+		}
+	case schema.HasType(jsonschema.Integer), schema.HasType(jsonschema.Number):
+		if !rejectsZeroNumber(schema) {
+			addNot(schema, (&jsonschema.Schema{}).WithEnum(0).ToSchemaOrBool())
+		}
+	case schema.HasType(jsonschema.Boolean):
+		schema.WithEnum(true)
+	}
+}
+
+func allowZeroValue(schema *jsonschema.Schema) {
+	constraints := jsonschema.Schema{}
+	zero := jsonschema.Schema{}
+
+	switch {
+	case schema.HasType(jsonschema.String):
+		if len(schema.Enum) > 0 {
+			schema.Enum = appendZero(schema.Enum, "")
+		}
+		if schema.MinLength > 0 {
+			constraints.MinLength, schema.MinLength = schema.MinLength, 0
+		}
+		if schema.Format != nil {
+			constraints.Format, schema.Format = schema.Format, nil
+		}
+		if schema.Pattern != nil && !matchesEmpty(*schema.Pattern) {
+			constraints.Pattern, schema.Pattern = schema.Pattern, nil
+		}
+		kept := schema.AllOf[:0]
+		for _, item := range schema.AllOf {
+			if item.TypeObject != nil && item.TypeObject.Pattern != nil && !matchesEmpty(*item.TypeObject.Pattern) {
+				constraints.AllOf = append(constraints.AllOf, item)
+				continue
+			}
+			kept = append(kept, item)
+		}
+		schema.AllOf = kept
+		if len(schema.AllOf) == 0 {
+			schema.AllOf = nil
+		}
+		zero.WithMaxLength(0)
+	case schema.HasType(jsonschema.Integer), schema.HasType(jsonschema.Number):
+		if len(schema.Enum) > 0 {
+			schema.Enum = appendZero(schema.Enum, 0)
+		}
+		if rejectsZeroNumber(schema) {
+			constraints.Minimum, schema.Minimum = schema.Minimum, nil
+			constraints.Maximum, schema.Maximum = schema.Maximum, nil
+			constraints.ExclusiveMinimum, schema.ExclusiveMinimum = schema.ExclusiveMinimum, nil
+			constraints.ExclusiveMaximum, schema.ExclusiveMaximum = schema.ExclusiveMaximum, nil
+		}
+		zero.WithEnum(0)
+	case schema.HasType(jsonschema.Array):
+		if schema.MinItems > 0 {
+			constraints.MinItems, schema.MinItems = schema.MinItems, 0
+		}
+		zero.WithMaxItems(0)
+	case schema.HasType(jsonschema.Object):
+		if schema.MinProperties > 0 {
+			constraints.MinProperties, schema.MinProperties = schema.MinProperties, 0
+		}
+		zero.WithMaxProperties(0)
+	default:
+		return
+	}
+
+	if reflect.DeepEqual(constraints, jsonschema.Schema{}) {
+		return
+	}
+
+	schema.AnyOf = append(schema.AnyOf, zero.ToSchemaOrBool(), constraints.ToSchemaOrBool())
+}
+
+func rejectsZeroNumber(schema *jsonschema.Schema) bool {
+	if schema.Minimum != nil && (*schema.Minimum > 0 || (*schema.Minimum == 0 && schema.ExclusiveMinimum != nil)) {
+		return true
+	}
+	if schema.Maximum != nil && (*schema.Maximum < 0 || (*schema.Maximum == 0 && schema.ExclusiveMaximum != nil)) {
+		return true
+	}
+	return len(schema.Enum) > 0 && !containsValue(schema.Enum, 0)
+}
+
+func appendZero(enum []any, zero any) []any {
+	if containsValue(enum, zero) {
+		return enum
+	}
+	return append(enum, zero)
+}
+
+// containsValue compares by formatted value, enum entries may be strings or any numeric type.
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if fmt.Sprint(v) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesEmpty reports whether the pattern accepts "". Patterns Go cannot compile are
+// treated as rejecting it.
+func matchesEmpty(pattern string) bool {
+	re, err := regexp.Compile(pattern)
+	return err == nil && re.MatchString("")
+}
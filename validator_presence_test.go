@@ -0,0 +1,160 @@
+package specgen_test
+
+import (
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"gopkg.in/yaml.v3"
+)
+
+type PresenceRequest struct {
+	Name     string   `json:"name" validate:"required"`
+	Nickname *string  `json:"nickname" validate:"required"`
+	Bio      *string  `json:"bio" validate:"omitempty,min=10"`
+	Website  string   `json:"website" validate:"omitempty,url"`
+	Code     string   `json:"code" validate:"omitempty,len=6,numeric"`
+	Level    string   `json:"level" validate:"omitempty,oneof=low high"`
+	Age      int      `json:"age" validate:"omitempty,min=18"`
+	Score    int      `json:"score" validate:"omitempty,min=0,max=10"`
+	Count    int      `json:"count" validate:"required"`
+	Accepted bool     `json:"accepted" validate:"required"`
+	Tags     []string `json:"tags" validate:"required"`
+	Comment  *string  `json:"comment" validate:"omitnil,max=200"`
+
+	Labels map[string]int `json:"labels" validate:"omitempty,min=1"`
+}
+
+type PresenceSchema struct {
+	Type      any              `yaml:"type"`
+	Nullable  bool             `yaml:"nullable"`
+	Format    string           `yaml:"format"`
+	Pattern   string           `yaml:"pattern"`
+	MinLength *int64           `yaml:"minLength"`
+	MaxLength *int64           `yaml:"maxLength"`
+	Minimum   *float64         `yaml:"minimum"`
+	Maximum   *float64         `yaml:"maximum"`
+	MaxItems  *int64           `yaml:"maxItems"`
+	MinProps  *int64           `yaml:"minProperties"`
+	MaxProps  *int64           `yaml:"maxProperties"`
+	Enum      []any            `yaml:"enum"`
+	AnyOf     []PresenceSchema `yaml:"anyOf"`
+	Not       *PresenceSchema  `yaml:"not"`
+}
+
+func TestParseValidatorV10Tag_OmitEmpty(t *testing.T) {
+	info := specgen.ParseValidatorV10Tag("omitempty,omitnil,min=3")
+	if !info.OmitEmpty || !info.OmitNil {
+		t.Errorf("Expected omitempty and omitnil to be parsed, got: %+v", info)
+	}
+	if len(info.Unknown) != 0 {
+		t.Errorf("omitempty and omitnil should not be unknown, got: %v", info.Unknown)
+	}
+}
+
+func TestGenerateOpenAPISpec_PresenceSemantics(t *testing.T) {
+	routes := []specgen.Route{
+		{
+			Path:    "/profiles",
+			Method:  "POST",
+			Request: PresenceRequest{},
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(specgen.SpecConfig{}, specgen.FormatYAML, routes)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string                  `yaml:"required"`
+				Properties map[string]PresenceSchema `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	schema := spec.Components.Schemas["GoSpecgenTestPresenceRequest"]
+	props := schema.Properties
+
+	expectedRequired := map[string]bool{"name": true, "nickname": true, "count": true, "accepted": true, "tags": true}
+	if len(schema.Required) != len(expectedRequired) {
+		t.Errorf("Expected required %v, got %v", expectedRequired, schema.Required)
+	}
+	for _, name := range schema.Required {
+		if !expectedRequired[name] {
+			t.Errorf("Unexpected required field %s", name)
+		}
+	}
+
+	// required rejects the zero value of non-pointer fields
+	if name := props["name"]; name.MinLength == nil || *name.MinLength != 1 {
+		t.Error("Required string should have minLength 1")
+	}
+	if count := props["count"]; count.Not == nil || len(count.Not.Enum) != 1 {
+		t.Error("Required integer should exclude 0")
+	}
+	if accepted := props["accepted"]; len(accepted.Enum) != 1 || accepted.Enum[0] != true {
+		t.Errorf("Required bool should only accept true, got: %v", accepted.Enum)
+	}
+
+	// required pointers, slices and maps only reject nil
+	if nickname := props["nickname"]; nickname.Nullable || nickname.MinLength != nil {
+		t.Error("Required pointer should be non-nullable without minLength")
+	}
+	if tags := props["tags"]; tags.Nullable {
+		t.Error("Required slice should not be nullable")
+	}
+
+	// optional pointers stay nullable with unconditional constraints
+	if bio := props["bio"]; !bio.Nullable || bio.MinLength == nil || *bio.MinLength != 10 || len(bio.AnyOf) != 0 {
+		t.Errorf("Optional pointer should be nullable with minLength 10, got: %+v", bio)
+	}
+	if comment := props["comment"]; !comment.Nullable || comment.MaxLength == nil || *comment.MaxLength != 200 {
+		t.Errorf("omitnil pointer should be nullable with maxLength 200, got: %+v", comment)
+	}
+
+	// omitempty allows the zero value besides the constraints
+	website := props["website"]
+	if website.Format != "" || len(website.AnyOf) != 2 {
+		t.Fatalf("Expected website format to move into anyOf, got: %+v", website)
+	}
+	if website.AnyOf[0].MaxLength == nil || *website.AnyOf[0].MaxLength != 0 || website.AnyOf[1].Format != "uri" {
+		t.Errorf("Expected website anyOf [maxLength 0, format uri], got: %+v", website.AnyOf)
+	}
+
+	code := props["code"]
+	if code.MaxLength == nil || *code.MaxLength != 6 {
+		t.Error("maxLength does not reject the empty string and should stay on the property")
+	}
+	if len(code.AnyOf) != 2 || code.AnyOf[1].MinLength == nil || code.AnyOf[1].Pattern == "" {
+		t.Errorf("Expected code minLength and pattern to move into anyOf, got: %+v", code)
+	}
+
+	if level := props["level"]; len(level.Enum) != 3 || level.Enum[2] != "" {
+		t.Errorf("Expected empty string to be added to the enum, got: %v", level.Enum)
+	}
+
+	age := props["age"]
+	if age.Minimum != nil || len(age.AnyOf) != 2 || age.AnyOf[1].Minimum == nil || *age.AnyOf[1].Minimum != 18 {
+		t.Errorf("Expected age minimum to move into anyOf, got: %+v", age)
+	}
+
+	if score := props["score"]; len(score.AnyOf) != 0 || score.Minimum == nil || score.Maximum == nil {
+		t.Errorf("Range that includes 0 should stay on the property, got: %+v", score)
+	}
+
+	labels := props["labels"]
+	if labels.MinProps != nil || len(labels.AnyOf) != 2 {
+		t.Fatalf("Expected labels minProperties to move into anyOf, got: %+v", labels)
+	}
+	if labels.AnyOf[0].MaxProps == nil || *labels.AnyOf[0].MaxProps != 0 || labels.AnyOf[1].MinProps == nil || *labels.AnyOf[1].MinProps != 1 {
+		t.Errorf("Expected labels anyOf [maxProperties 0, minProperties 1], got: %+v", labels.AnyOf)
+	}
+}
@@ -0,0 +1,113 @@
+package specgen
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// CustomValidator mutates the schema of a field using a custom validator tag.
+// The param is the text after `=`, empty for tags without a parameter.
+type CustomValidator func(schema *jsonschema.Schema, param string) error
+
+type ValidatorRegistry struct {
+	mu         sync.RWMutex
+	validators map[string]CustomValidator
+}
+
+func NewValidatorRegistry() *ValidatorRegistry {
+	return &ValidatorRegistry{validators: make(map[string]CustomValidator)}
+}
+
+// DefaultValidatorRegistry is used when ValidatorConfig.Registry is nil.
+var DefaultValidatorRegistry = NewValidatorRegistry()
+
+// RegisterValidator adds a custom validator tag to DefaultValidatorRegistry.
+func RegisterValidator(tag string, validator CustomValidator) {
+	DefaultValidatorRegistry.Register(tag, validator)
+}
+
+func (r *ValidatorRegistry) Register(tag string, validator CustomValidator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.validators[tag] = validator
+}
+
+func (r *ValidatorRegistry) Lookup(tag string) (CustomValidator, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	validator, ok := r.validators[tag]
+	return validator, ok
+}
+
+type UnknownTagPolicy int
+
+const (
+	UnknownTagIgnore UnknownTagPolicy = iota
+	UnknownTagWarn
+	UnknownTagFail
+)
+
+type ValidatorConfig struct {
+	Registry *ValidatorRegistry
+	// UnknownTags decides what happens to validator tags that are neither built in nor registered.
+	UnknownTags UnknownTagPolicy
+	// Logger receives warnings for UnknownTagWarn, defaults to the standard logger.
+	Logger *log.Logger
+
+	// TagKey is the struct tag holding validator v10 rules, "validate" by default.
+	TagKey string
+	// Adapters parse other tag dialects or validation libraries. The first adapter that
+	// has rules for a field wins, TagKey is used when none has.
+	Adapters []ValidationAdapter
+}
+
+// noSchemaRules are validator v10 rules that do not constrain the schema.
+var noSchemaRules = map[string]bool{
+	"":              true,
+	"omitzero":      true,
+	"structonly":    true,
+	"nostructlevel": true,
+	"isdefault":     true,
+}
+
+// applyUnknown applies registered custom validators and handles the remaining unknown
+// tags according to the policy. Warnings are logged once per field and tag.
+func (c ValidatorConfig) applyUnknown(schema *jsonschema.Schema, unknown []string, field string, warned map[string]bool) error {
+	registry := c.Registry
+	if registry == nil {
+		registry = DefaultValidatorRegistry
+	}
+
+	for _, part := range unknown {
+		tag, param, _ := strings.Cut(part, "=")
+
+		if validator, ok := registry.Lookup(tag); ok {
+			if err := validator(schema, param); err != nil {
+				return fmt.Errorf("validator %q on field %s: %w", tag, field, err)
+			}
+			continue
+		}
+
+		switch c.UnknownTags {
+		case UnknownTagWarn:
+			if warned[field+" "+part] {
+				continue
+			}
+			warned[field+" "+part] = true
+
+			logger := c.Logger
+			if logger == nil {
+				logger = log.Default()
+			}
+			logger.Printf("specgen: unknown validator tag %q on field %s", part, field)
+		case UnknownTagFail:
+			return fmt.Errorf("unknown validator tag %q on field %s", part, field)
+		}
+	}
+
+	return nil
+}
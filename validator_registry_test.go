@@ -0,0 +1,131 @@
+package specgen_test
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/lutfiandri/go-specgen"
+	"github.com/swaggest/jsonschema-go"
+	"gopkg.in/yaml.v3"
+)
+
+type ProductRequest struct {
+	SKU      string   `json:"sku" validate:"required,sku"`
+	Currency string   `json:"currency" validate:"currency_code=ISO4217"`
+	Aliases  []string `json:"aliases" validate:"dive,sku"`
+}
+
+type UnknownTagRequest struct {
+	Name string `json:"name" validate:"required,omitempty,mystery"`
+}
+
+func productRoutes(request any) []specgen.Route {
+	return []specgen.Route{
+		{
+			Path:    "/products",
+			Method:  "POST",
+			Request: request,
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+		{
+			Path:    "/products",
+			Method:  "PUT",
+			Request: request,
+			Responses: []specgen.RouteResponse{
+				{StatusCode: 204, Response: nil},
+			},
+		},
+	}
+}
+
+func TestValidatorRegistry_CustomTags(t *testing.T) {
+	registry := specgen.NewValidatorRegistry()
+	registry.Register("sku", func(schema *jsonschema.Schema, param string) error {
+		schema.WithPattern(`^[A-Z]{3}-[0-9]{4}$`)
+		return nil
+	})
+	registry.Register("currency_code", func(schema *jsonschema.Schema, param string) error {
+		schema.WithMinLength(3).WithMaxLength(3).WithDescription("Currency code (" + param + ")")
+		return nil
+	})
+
+	config := specgen.SpecConfig{
+		Validator: specgen.ValidatorConfig{
+			Registry:    registry,
+			UnknownTags: specgen.UnknownTagFail,
+		},
+	}
+
+	content, err := specgen.GenerateOpenAPISpecBytes(config, specgen.FormatYAML, productRoutes(ProductRequest{}))
+	if err != nil {
+		t.Fatalf("GenerateOpenAPISpecBytes failed: %v", err)
+	}
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Pattern     string `yaml:"pattern"`
+					MinLength   int    `yaml:"minLength"`
+					Description string `yaml:"description"`
+					Items       struct {
+						Pattern string `yaml:"pattern"`
+					} `yaml:"items"`
+				} `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("Failed to unmarshal YAML: %v", err)
+	}
+
+	props := spec.Components.Schemas["GoSpecgenTestProductRequest"].Properties
+	if props["sku"].Pattern != `^[A-Z]{3}-[0-9]{4}$` {
+		t.Errorf("Expected sku pattern from custom validator, got %q", props["sku"].Pattern)
+	}
+	if props["currency"].MinLength != 3 || props["currency"].Description != "Currency code (ISO4217)" {
+		t.Errorf("Expected currency constraints from custom validator, got %+v", props["currency"])
+	}
+	if props["aliases"].Items.Pattern != `^[A-Z]{3}-[0-9]{4}$` {
+		t.Errorf("Expected custom validator to apply after dive, got %q", props["aliases"].Items.Pattern)
+	}
+}
+
+func TestValidatorRegistry_UnknownTags(t *testing.T) {
+	var logs bytes.Buffer
+	config := specgen.SpecConfig{
+		Validator: specgen.ValidatorConfig{
+			Registry:    specgen.NewValidatorRegistry(),
+			UnknownTags: specgen.UnknownTagWarn,
+			Logger:      log.New(&logs, "", 0),
+		},
+	}
+
+	if _, err := specgen.GenerateOpenAPISpecBytes(config, specgen.FormatYAML, productRoutes(UnknownTagRequest{})); err != nil {
+		t.Fatalf("Unknown tags should only warn, got: %v", err)
+	}
+	if got := strings.Count(logs.String(), `unknown validator tag "mystery" on field UnknownTagRequest.Name`); got != 1 {
+		t.Errorf("Expected one warning for the mystery tag, got:\n%s", logs.String())
+	}
+	if strings.Contains(logs.String(), "omitempty") {
+		t.Error("omitempty should not be reported as unknown")
+	}
+
+	config.Validator.UnknownTags = specgen.UnknownTagFail
+	_, err := specgen.GenerateOpenAPISpecBytes(config, specgen.FormatYAML, productRoutes(UnknownTagRequest{}))
+	if err == nil {
+		t.Fatal("Expected error for unknown validator tag, but got nil")
+	}
+	if !strings.Contains(err.Error(), `unknown validator tag "mystery"`) {
+		t.Errorf("Expected error naming the unknown tag, got: %v", err)
+	}
+
+	config.Validator.UnknownTags = specgen.UnknownTagIgnore
+	if _, err := specgen.GenerateOpenAPISpecBytes(config, specgen.FormatYAML, productRoutes(UnknownTagRequest{})); err != nil {
+		t.Errorf("Unknown tags should be ignored by default, got: %v", err)
+	}
+}